@@ -0,0 +1,161 @@
+package forceloader
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	resolverDirective = "//forceloader:resolver"
+	rootDirective     = "//forceloader:root"
+)
+
+// resolverAnnotations holds the functions and types marked with
+// `//forceloader:resolver` or `//forceloader:root`.
+type resolverAnnotations struct {
+	resolverFuncs map[types.Object]bool
+	rootFuncs     map[types.Object]bool
+	resolverTypes map[types.Object]bool
+	rootTypes     map[types.Object]bool
+	// ignoredTypes holds the types with a forceloader:ignore directive.
+	ignoredTypes  map[types.Object]bool
+	boundaryFuncs map[types.Object]bool
+	boundaryTypes map[types.Object]bool
+}
+
+func hasDirective(group *ast.CommentGroup, directive string) bool {
+	if group == nil {
+		return false
+	}
+
+	return lo.SomeBy(group.List, func(comment *ast.Comment) bool {
+		return comment.Text == directive || strings.HasPrefix(comment.Text, directive+" ")
+	})
+}
+
+func getResolverAnnotations(
+	pass *analysis.Pass,
+) *resolverAnnotations {
+	annotations := &resolverAnnotations{
+		resolverFuncs: make(map[types.Object]bool),
+		rootFuncs:     make(map[types.Object]bool),
+		resolverTypes: make(map[types.Object]bool),
+		rootTypes:     make(map[types.Object]bool),
+		ignoredTypes:  getIgnoredResolvers(pass),
+		boundaryFuncs: make(map[types.Object]bool),
+		boundaryTypes: make(map[types.Object]bool),
+	}
+
+	lo.ForEach(pass.Files, func(file *ast.File, _ int) {
+		lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				return
+			}
+
+			obj := pass.TypesInfo.Defs[funcDecl.Name]
+			if obj == nil {
+				return
+			}
+
+			if hasDirective(funcDecl.Doc, resolverDirective) {
+				annotations.resolverFuncs[obj] = true
+			}
+
+			if hasDirective(funcDecl.Doc, rootDirective) {
+				annotations.rootFuncs[obj] = true
+			}
+
+			if hasDirective(funcDecl.Doc, boundaryDirective) {
+				annotations.boundaryFuncs[obj] = true
+			}
+		})
+
+		for typeSpec, groups := range getTypeDirectiveGroups(file) {
+			obj := pass.TypesInfo.Defs[typeSpec.Name]
+			if obj == nil {
+				continue
+			}
+
+			if lo.SomeBy(groups, func(group *ast.CommentGroup) bool { return hasDirective(group, resolverDirective) }) {
+				annotations.resolverTypes[obj] = true
+			}
+
+			if lo.SomeBy(groups, func(group *ast.CommentGroup) bool { return hasDirective(group, rootDirective) }) {
+				annotations.rootTypes[obj] = true
+			}
+
+			if lo.SomeBy(groups, func(group *ast.CommentGroup) bool { return hasDirective(group, boundaryDirective) }) {
+				annotations.boundaryTypes[obj] = true
+			}
+		}
+	})
+
+	return annotations
+}
+
+// getFuncAnnotation reports whether fn, or the function declaring the closure fn,
+// is marked as a resolver or as a root.
+func (a *resolverAnnotations) getFuncAnnotation(
+	fn *ssa.Function,
+) (isResolver bool, isRoot bool) {
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+
+	obj := fn.Object()
+	if obj == nil {
+		return false, false
+	}
+
+	return a.resolverFuncs[obj], a.rootFuncs[obj]
+}
+
+// isBoundary reports whether fn, or the function declaring the closure fn,
+// is marked as a boundary, or is a method of a type marked so.
+func (a *resolverAnnotations) isBoundary(
+	fn *ssa.Function,
+) bool {
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+
+	if obj := fn.Object(); obj != nil && a.boundaryFuncs[obj] {
+		return true
+	}
+
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		return false
+	}
+
+	named, ok := derefNamed(recv.Type())
+
+	return ok && a.boundaryTypes[named.Origin().Obj()]
+}
+
+// hasResolverType reports whether fn takes or captures a value of a type marked as a resolver.
+func (a *resolverAnnotations) hasResolverType(
+	fn *ssa.Function,
+) bool {
+	values := append(
+		lo.Map(fn.FreeVars, func(v *ssa.FreeVar, _ int) types.Type { return v.Type() }),
+		lo.Map(fn.Params, func(v *ssa.Parameter, _ int) types.Type { return v.Type() })...,
+	)
+
+	return lo.SomeBy(values, func(t types.Type) bool {
+		named, ok := derefNamed(t)
+		if !ok {
+			return false
+		}
+
+		obj := named.Origin().Obj()
+
+		return a.resolverTypes[obj] && !a.rootTypes[obj] && !a.ignoredTypes[obj]
+	})
+}
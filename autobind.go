@@ -0,0 +1,138 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/ssa"
+	"gopkg.in/yaml.v3"
+)
+
+// gqlgenConfig is the part of gqlgen.yml telling which Go types the schema types are bound to.
+type gqlgenConfig struct {
+	Schema   []string               `yaml:"schema"`
+	Autobind []string               `yaml:"autobind"`
+	Models   map[string]gqlgenModel `yaml:"models"`
+}
+
+type gqlgenModel struct {
+	Model  stringList             `yaml:"model"`
+	Fields map[string]gqlgenField `yaml:"fields"`
+}
+
+type gqlgenField struct {
+	// Resolver tells gqlgen to generate a resolver for the field instead of binding it.
+	Resolver bool `yaml:"resolver"`
+	// FieldName is the Go field or method the field is bound to, when not named like it.
+	FieldName string `yaml:"fieldName"`
+}
+
+// stringList is a YAML list of strings, or a single string.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = stringList{node.Value}
+
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+
+	*l = list
+
+	return nil
+}
+
+// modelBindings holds the schema fields, like "Todo.user", gqlgen binds to the methods and fields
+// of the models, by the qualified name of the model and the normalized name of the method or field.
+type modelBindings map[string]map[string]string
+
+// loadModelBindings reads the gqlgen configuration file at path and the schema it points to.
+func loadModelBindings(path string) (modelBindings, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gqlgen config: %w", err)
+	}
+
+	config := gqlgenConfig{}
+	if err := yaml.Unmarshal(src, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse gqlgen config %s: %w", path, err)
+	}
+
+	schema, err := loadSchema(lo.Map(config.Schema, func(pattern string, _ int) string {
+		if filepath.IsAbs(pattern) {
+			return pattern
+		}
+
+		return filepath.Join(filepath.Dir(path), pattern)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make(modelBindings)
+
+	for object, fields := range schema.objects {
+		model := config.Models[object]
+
+		// Models bound explicitly are not autobound.
+		models := []string(model.Model)
+		if len(models) == 0 {
+			models = lo.Map(config.Autobind, func(pkg string, _ int) string {
+				return pkg + "." + object
+			})
+		}
+
+		methods := make(map[string]string)
+
+		lo.ForEach(fields, func(name string, _ int) {
+			field := model.Fields[name]
+			if field.Resolver {
+				return
+			}
+
+			methods[normalizeFieldName(lo.Ternary(field.FieldName != "", field.FieldName, name))] = object + "." + name
+		})
+
+		lo.ForEach(models, func(model string, _ int) {
+			bindings[model] = lo.Assign(bindings[model], methods)
+		})
+	}
+
+	return bindings, nil
+}
+
+// Contains reports whether named is a model bound to the schema.
+func (b modelBindings) Contains(named *types.Named) bool {
+	_, ok := b[qualifiedName(named.Origin().Obj())]
+
+	return ok
+}
+
+// IsResolver reports whether fn is a model method gqlgen calls to resolve a field.
+func (b modelBindings) IsResolver(fn *ssa.Function) bool {
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		return false
+	}
+
+	named, ok := derefNamed(recv.Type())
+	if !ok || !token.IsExported(fn.Name()) {
+		return false
+	}
+
+	return b.getField(named, fn.Name()) != ""
+}
+
+// getField returns the schema field bound to the method or field name of named, if any.
+func (b modelBindings) getField(named *types.Named, name string) string {
+	return b[qualifiedName(named.Origin().Obj())][normalizeFieldName(name)]
+}
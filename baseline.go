@@ -0,0 +1,136 @@
+package forceloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// Baseline is a set of tolerated findings recorded by `forceloader baseline write`.
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Package     string `json:"package"`
+	Resolver    string `json:"resolver"`
+	Symbol      string `json:"symbol"`
+	Snippet     string `json:"snippet"`
+	Count       int    `json:"count"`
+}
+
+func NewBaseline(findings []Finding) *Baseline {
+	entries := make(map[string]*BaselineEntry)
+
+	lo.ForEach(findings, func(finding Finding, _ int) {
+		fingerprint := finding.Fingerprint()
+
+		entry, ok := entries[fingerprint]
+		if !ok {
+			entry = &BaselineEntry{
+				Fingerprint: fingerprint,
+				Package:     finding.Package,
+				Resolver:    finding.Resolver,
+				Symbol:      finding.Symbol,
+				Snippet:     finding.Snippet,
+			}
+			entries[fingerprint] = entry
+		}
+
+		entry.Count++
+	})
+
+	b := &Baseline{
+		Entries: lo.MapToSlice(entries, func(_ string, entry *BaselineEntry) BaselineEntry {
+			return *entry
+		}),
+	}
+	b.sort()
+
+	return b
+}
+
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+
+	return &b, nil
+}
+
+func (b *Baseline) Write(path string) error {
+	b.sort()
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Filter returns the findings of pkg that are not tolerated by the baseline,
+// and the entries of pkg that tolerate more findings than were found.
+func (b *Baseline) Filter(pkg string, findings []Finding) ([]Finding, []BaselineEntry) {
+	entries := lo.Filter(b.Entries, func(entry BaselineEntry, _ int) bool {
+		return entry.Package == pkg
+	})
+
+	remaining := lo.SliceToMap(entries, func(entry BaselineEntry) (string, int) {
+		return entry.Fingerprint, entry.Count
+	})
+
+	sorted := append([]Finding{}, findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pos < sorted[j].Pos
+	})
+
+	newFindings := lo.Filter(sorted, func(finding Finding, _ int) bool {
+		fingerprint := finding.Fingerprint()
+
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+
+			return false
+		}
+
+		return true
+	})
+
+	staleEntries := lo.Filter(entries, func(entry BaselineEntry, _ int) bool {
+		return remaining[entry.Fingerprint] > 0
+	})
+
+	return newFindings, staleEntries
+}
+
+func (b *Baseline) sort() {
+	sort.Slice(b.Entries, func(i, j int) bool {
+		x, y := b.Entries[i], b.Entries[j]
+
+		if x.Package != y.Package {
+			return x.Package < y.Package
+		}
+
+		if x.Resolver != y.Resolver {
+			return x.Resolver < y.Resolver
+		}
+
+		return x.Fingerprint < y.Fingerprint
+	})
+}
@@ -0,0 +1,336 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const boundaryDirective = "//forceloader:boundary"
+
+// Boundary matches the functions that handle a whole request outside of GraphQL,
+// such as HTTP handlers or gRPC methods, which must not fetch data per item.
+// Functions and types marked with `//forceloader:boundary` are boundaries too.
+type Boundary struct {
+	// Signature matches functions and closures by their signature,
+	// like "func(net/http.ResponseWriter, *net/http.Request)".
+	Signature string `yaml:"signature"`
+	// Interface matches the methods of the interface on the types implementing it, like "a/pb.TodoServiceServer".
+	Interface string `yaml:"interface"`
+}
+
+func (b Boundary) validate() error {
+	switch {
+	case b.Signature != "" && b.Interface != "":
+		return fmt.Errorf("boundary sets both signature %q and interface %q", b.Signature, b.Interface)
+	case b.Signature != "":
+		if !strings.HasPrefix(b.Signature, "func(") {
+			return fmt.Errorf("boundary signature %q must be like func(net/http.ResponseWriter, *net/http.Request)", b.Signature)
+		}
+
+		return nil
+	case b.Interface != "":
+		return validateTypeName(b.Interface)
+	default:
+		return fmt.Errorf("boundary sets neither signature nor interface")
+	}
+}
+
+// boundaryMatcher finds the boundaries of a package.
+type boundaryMatcher struct {
+	signatures  []string
+	interfaces  []*types.Interface
+	annotations *resolverAnnotations
+}
+
+func newBoundaryMatcher(
+	pass *analysis.Pass,
+	boundaries []Boundary,
+	annotations *resolverAnnotations,
+) *boundaryMatcher {
+	matcher := &boundaryMatcher{annotations: annotations}
+	if len(boundaries) == 0 {
+		return matcher
+	}
+
+	// Only the signatures of the functions and closures the package declares, and the interfaces its types implement,
+	// can match, so that the other packages need no SSA.
+	signatures := make(map[string]bool)
+	namedTypes := make([]types.Type, 0)
+
+	for _, obj := range pass.TypesInfo.Defs {
+		switch obj := obj.(type) {
+		case *types.Func:
+			signatures[signatureString(obj.Type().(*types.Signature))] = true
+		case *types.TypeName:
+			if !obj.IsAlias() && !types.IsInterface(obj.Type()) {
+				namedTypes = append(namedTypes, obj.Type(), types.NewPointer(obj.Type()))
+			}
+		}
+	}
+
+	for expr, tv := range pass.TypesInfo.Types {
+		if sig, ok := tv.Type.(*types.Signature); ok {
+			if _, ok := expr.(*ast.FuncLit); ok {
+				signatures[signatureString(sig)] = true
+			}
+		}
+	}
+
+	lo.ForEach(boundaries, func(boundary Boundary, _ int) {
+		if signature := normalizeSignature(boundary.Signature); boundary.Signature != "" && signatures[signature] {
+			matcher.signatures = append(matcher.signatures, signature)
+		}
+
+		if boundary.Interface == "" {
+			return
+		}
+
+		// An interface the package cannot see cannot be implemented by its types either.
+		obj := lookupTypeName(pass.Pkg, boundary.Interface)
+		if obj == nil {
+			return
+		}
+
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			return
+		}
+
+		isImplemented := lo.SomeBy(namedTypes, func(t types.Type) bool {
+			return types.Implements(t, iface)
+		})
+		if isImplemented {
+			matcher.interfaces = append(matcher.interfaces, iface)
+		}
+	})
+
+	return matcher
+}
+
+// IsEmpty reports whether the package has no boundaries for sure, so that SSA need not be built for them.
+func (m *boundaryMatcher) IsEmpty() bool {
+	return len(m.signatures) == 0 && len(m.interfaces) == 0 &&
+		len(m.annotations.boundaryFuncs) == 0 && len(m.annotations.boundaryTypes) == 0
+}
+
+// Matches reports whether fn is a boundary.
+func (m *boundaryMatcher) Matches(fn *ssa.Function) bool {
+	if m.annotations.isBoundary(fn) {
+		return true
+	}
+
+	if lo.Contains(m.signatures, signatureString(fn.Signature)) {
+		return true
+	}
+
+	recv := fn.Signature.Recv()
+	if recv == nil || len(m.interfaces) == 0 {
+		return false
+	}
+
+	return lo.SomeBy(m.interfaces, func(iface *types.Interface) bool {
+		isMethod := lo.SomeBy(lo.Range(iface.NumMethods()), func(i int) bool {
+			return iface.Method(i).Name() == fn.Name()
+		})
+
+		return isMethod && types.Implements(recv.Type(), iface)
+	})
+}
+
+// signatureString renders sig without its receiver and parameter names, with full package paths.
+func signatureString(sig *types.Signature) string {
+	unnamed := func(tuple *types.Tuple) *types.Tuple {
+		vars := lo.Map(lo.Range(tuple.Len()), func(i int, _ int) *types.Var {
+			return types.NewParam(tuple.At(i).Pos(), nil, "", tuple.At(i).Type())
+		})
+
+		return types.NewTuple(vars...)
+	}
+
+	sig = types.NewSignatureType(nil, nil, nil, unnamed(sig.Params()), unnamed(sig.Results()), sig.Variadic())
+
+	return normalizeSignature(types.TypeString(sig, func(pkg *types.Package) string { return pkg.Path() }))
+}
+
+func normalizeSignature(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// restrictedHelperFact marks an exported function that makes a restricted call, directly or through other helpers,
+// so that the packages calling it know, as SSA only has the bodies of the package analyzed.
+type restrictedHelperFact struct {
+	// Symbol is the restricted symbol the function reaches.
+	Symbol string
+}
+
+func (*restrictedHelperFact) AFact() {}
+
+func (f *restrictedHelperFact) String() string {
+	return "restrictedHelper(" + f.Symbol + ")"
+}
+
+// mayContainHelpers reports whether the package imports a restricted package, or a helper of another package.
+func mayContainHelpers(
+	pass *analysis.Pass,
+	restrictedPackages []string,
+) bool {
+	isImported := lo.SomeBy(pass.Pkg.Imports(), func(pkg *types.Package) bool {
+		return lo.Contains(restrictedPackages, pkg.Path())
+	})
+
+	return isImported || len(pass.AllObjectFacts()) > 0
+}
+
+// importHelperSymbol returns the restricted symbol the function fn of another package reaches, if any.
+func importHelperSymbol(
+	pass *analysis.Pass,
+	fn *ssa.Function,
+) string {
+	fact := new(restrictedHelperFact)
+	if fn.Object() == nil || !pass.ImportObjectFact(fn.Object(), fact) {
+		return ""
+	}
+
+	return fact.Symbol
+}
+
+// exportHelpers exports a restrictedHelperFact for the exported helpers of the package.
+func exportHelpers(
+	pass *analysis.Pass,
+	helpers map[*ssa.Function]string,
+) {
+	for fn, symbol := range helpers {
+		if fn.Object() == nil || fn.Object().Pkg() != pass.Pkg || !fn.Object().Exported() {
+			continue
+		}
+
+		pass.ExportObjectFact(fn.Object(), &restrictedHelperFact{Symbol: symbol})
+	}
+}
+
+// getRestrictedHelpers returns the functions of funcs that make a restricted call,
+// directly or through other functions, with the restricted symbol they reach.
+// The functions of other packages are known by their restrictedHelperFact, which importSymbol returns.
+func getRestrictedHelpers(
+	funcs []*ssa.Function,
+	restrictedPackages []string,
+	importSymbol func(fn *ssa.Function) string,
+) map[*ssa.Function]string {
+	symbols := make(map[*ssa.Function]string)
+	visiting := make(map[*ssa.Function]bool)
+
+	lo.ForEach(funcs, func(fn *ssa.Function, _ int) {
+		lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+			lo.ForEach(block.Instrs, func(inst ssa.Instruction, _ int) {
+				call, ok := inst.(ssa.CallInstruction)
+				if !ok {
+					return
+				}
+
+				callee := call.Common().StaticCallee()
+				if callee == nil || callee.Pkg == fn.Pkg {
+					return
+				}
+
+				if _, ok := symbols[callee]; !ok {
+					symbols[callee] = importSymbol(callee)
+				}
+			})
+		})
+	})
+
+	var visit func(fn *ssa.Function) string
+
+	visit = func(fn *ssa.Function) string {
+		if symbol, ok := symbols[fn]; ok {
+			return symbol
+		}
+
+		if visiting[fn] {
+			return ""
+		}
+
+		visiting[fn] = true
+
+		symbol := ""
+
+		for _, block := range fn.Blocks {
+			for _, inst := range block.Instrs {
+				if target, ok := getRestrictedTarget(inst, restrictedPackages); ok {
+					symbol = target.Symbol
+
+					break
+				}
+
+				call, ok := inst.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+
+				callee := call.Common().StaticCallee()
+				if callee == nil {
+					continue
+				}
+
+				if callee.Pkg != fn.Pkg {
+					if symbol = symbols[callee]; symbol != "" {
+						break
+					}
+
+					continue
+				}
+
+				if symbol = visit(callee); symbol != "" {
+					break
+				}
+			}
+
+			if symbol != "" {
+				break
+			}
+		}
+
+		symbols[fn] = symbol
+
+		return symbol
+	}
+
+	lo.ForEach(funcs, func(fn *ssa.Function, _ int) {
+		visit(fn)
+	})
+
+	return lo.PickBy(symbols, func(_ *ssa.Function, symbol string) bool {
+		return symbol != ""
+	})
+}
+
+// getHelperTarget reports whether inst calls a helper making restricted calls.
+func getHelperTarget(
+	inst ssa.Instruction,
+	helpers map[*ssa.Function]string,
+) (restrictedTarget, bool) {
+	call, ok := inst.(ssa.CallInstruction)
+	if !ok {
+		return restrictedTarget{}, false
+	}
+
+	callee := call.Common().StaticCallee()
+	if callee == nil {
+		return restrictedTarget{}, false
+	}
+
+	symbol, ok := helpers[callee]
+	if !ok {
+		return restrictedTarget{}, false
+	}
+
+	_, isGo := inst.(*ssa.Go)
+
+	return restrictedTarget{Pos: call.Common().Pos(), Symbol: symbol, Through: callee.String(), Go: isGo}, true
+}
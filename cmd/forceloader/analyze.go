@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/flum1025/forceloader"
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// newFlagSet returns a flag set that also accepts the analyzer flags.
+func newFlagSet(name string) *flag.FlagSet {
+	command := flag.NewFlagSet(name, flag.ExitOnError)
+
+	forceloader.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		command.Var(f.Value, f.Name, f.Usage)
+	})
+
+	return command
+}
+
+// analyze runs the analyzer over the packages matching patterns outside of go vet,
+// and warns about the configuration that matches nothing in them.
+func analyze(patterns []string) (*checker.Graph, error) {
+	config, err := forceloader.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{forceloader.Analyzer}, pkgs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze packages: %w", err)
+	}
+
+	warnings, err := configWarnings(config, graph)
+	if err != nil {
+		return nil, err
+	}
+
+	lo.ForEach(warnings, func(warning string, _ int) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	})
+
+	return graph, nil
+}
+
+// configWarnings returns the settings of config that match nothing in the analysed packages.
+func configWarnings(config *forceloader.Config, graph *checker.Graph) ([]string, error) {
+	results, err := collectResults(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0)
+
+	if len(config.RestrictedPackages) == 0 {
+		warnings = append(warnings, "restrictedPackages is empty, so nothing is restricted")
+	}
+
+	isFound := lo.SomeBy(results, func(result *forceloader.Result) bool {
+		return result.ResolverStructFound
+	})
+	if config.ResolverStruct != "" && !isFound {
+		warnings = append(warnings, fmt.Sprintf("resolverStruct %s is not found in any analysed package", config.ResolverStruct))
+	}
+
+	imports := lo.FlatMap(results, func(result *forceloader.Result, _ int) []string {
+		return result.RestrictedImports
+	})
+
+	lo.ForEach(config.RestrictedPackages, func(path string, _ int) {
+		if !lo.Contains(imports, path) {
+			warnings = append(warnings, fmt.Sprintf("restricted package %s is not imported by any analysed package", path))
+		}
+	})
+
+	return warnings, nil
+}
+
+func collectResults(graph *checker.Graph) ([]*forceloader.Result, error) {
+	results := make([]*forceloader.Result, 0, len(graph.Roots))
+
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			return nil, fmt.Errorf("%s: %w", action.Package.PkgPath, action.Err)
+		}
+
+		result, ok := action.Result.(*forceloader.Result)
+		if !ok {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func collectFindings(graph *checker.Graph) ([]forceloader.Finding, error) {
+	results, err := collectResults(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.FlatMap(results, func(result *forceloader.Result, _ int) []forceloader.Finding {
+		return result.Findings
+	}), nil
+}
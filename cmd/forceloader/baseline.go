@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/flum1025/forceloader"
+)
+
+const defaultBaseline = "forceloader.baseline.json"
+
+func baselineCommand(args []string) error {
+	if len(args) == 0 || args[0] != "write" {
+		return fmt.Errorf("usage: forceloader baseline write [-o file] [flags] packages...")
+	}
+
+	command := newFlagSet("baseline write")
+	output := command.String("o", defaultBaseline, "baseline file to write")
+
+	if err := command.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if err := command.Set("baseline", ""); err != nil {
+		return err
+	}
+
+	graph, err := analyze(command.Args())
+	if err != nil {
+		return err
+	}
+
+	findings, err := collectFindings(graph)
+	if err != nil {
+		return err
+	}
+
+	if err := forceloader.NewBaseline(findings).Write(*output); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "wrote %d findings to %s\n", len(findings), *output)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/flum1025/forceloader"
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Exit codes of the check command, after the one of a failure to run.
+const (
+	exitWarning = 2
+	exitError   = 3
+)
+
+// exitCodeError makes the command exit with code without printing anything more.
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func checkCommand(args []string) error {
+	command := newFlagSet("check")
+
+	if err := command.Parse(args); err != nil {
+		return err
+	}
+
+	// Every diagnostic is collected here, whatever go vet would do with warnings.
+	if err := command.Set("warnings", "report"); err != nil {
+		return err
+	}
+
+	config, err := forceloader.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	graph, err := analyze(command.Args())
+	if err != nil {
+		return err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	warnings, err := configWarnings(config, graph)
+	if err != nil {
+		return err
+	}
+
+	highest := forceloader.SeverityInfo
+	if len(warnings) > 0 {
+		highest = forceloader.SeverityWarning
+	}
+
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			return fmt.Errorf("%s: %w", action.Package.PkgPath, action.Err)
+		}
+
+		lo.ForEach(action.Diagnostics, func(diagnostic analysis.Diagnostic, _ int) {
+			rule, err := forceloader.LookupRule(diagnostic.Category)
+			if err != nil {
+				return
+			}
+
+			severity := config.Severity(rule)
+			if highest.Less(severity) {
+				highest = severity
+			}
+
+			position := action.Package.Fset.Position(diagnostic.Pos)
+			if filename, err := filepath.Rel(wd, position.Filename); err == nil {
+				position.Filename = filename
+			}
+
+			fmt.Fprintf(os.Stdout, "%s: %s: %s (%s)\n", position, severity, diagnostic.Message, rule.ID)
+		})
+	}
+
+	switch highest {
+	case forceloader.SeverityError:
+		return exitCodeError(exitError)
+	case forceloader.SeverityWarning:
+		return exitCodeError(exitWarning)
+	default:
+		return nil
+	}
+}
@@ -1,8 +1,37 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+
 	"github.com/flum1025/forceloader"
 	"golang.org/x/tools/go/analysis/unitchecker"
 )
 
-func main() { unitchecker.Main(forceloader.Analyzer) }
+var commands = map[string]func(args []string) error{
+	"baseline":     baselineCommand,
+	"check":        checkCommand,
+	"ratchet":      ratchetCommand,
+	"suppressions": suppressionsCommand,
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				var exitCode exitCodeError
+				if errors.As(err, &exitCode) {
+					os.Exit(int(exitCode))
+				}
+
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	unitchecker.Main(forceloader.Analyzer)
+}
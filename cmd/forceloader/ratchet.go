@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/flum1025/forceloader"
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+func ratchetCommand(args []string) error {
+	command := newFlagSet("ratchet")
+
+	if err := command.Set("baseline", defaultBaseline); err != nil {
+		return err
+	}
+
+	if err := command.Parse(args); err != nil {
+		return err
+	}
+
+	// The baseline is compared here instead of filtering the findings in the analyzer.
+	baselinePath := command.Lookup("baseline").Value.String()
+
+	if err := command.Set("baseline", ""); err != nil {
+		return err
+	}
+
+	b, err := forceloader.LoadBaseline(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	graph, err := analyze(command.Args())
+	if err != nil {
+		return err
+	}
+
+	findings, err := collectFindings(graph)
+	if err != nil {
+		return err
+	}
+
+	pkgs := lo.Uniq(lo.Map(graph.Roots, func(action *checker.Action, _ int) string {
+		return action.Package.PkgPath
+	}))
+
+	ratchet := forceloader.NewRatchet(b, pkgs, findings)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PACKAGE\tRESOLVERS\tBASELINE\tCURRENT")
+
+	lo.ForEach(ratchet.Packages, func(summary forceloader.PackageSummary, _ int) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", summary.Package, summary.Resolvers, summary.Baseline, summary.Current)
+	})
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(ratchet.Gained) > 0 {
+		lo.ForEach(ratchet.Gained, func(c forceloader.ResolverCount, _ int) {
+			fmt.Fprintf(os.Stderr, "%s gained %d violations not in the baseline: %d -> %d\n", c.Resolver, c.New, c.Baseline, c.Current)
+		})
+
+		return fmt.Errorf("%d resolvers gained violations", len(ratchet.Gained))
+	}
+
+	if !ratchet.Removed {
+		return nil
+	}
+
+	if err := ratchet.Baseline.Write(baselinePath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "shrunk %s to %d findings\n", baselinePath, len(findings))
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/flum1025/forceloader"
+	"github.com/samber/lo"
+)
+
+func suppressionsCommand(args []string) error {
+	command := newFlagSet("suppressions")
+
+	if err := command.Parse(args); err != nil {
+		return err
+	}
+
+	graph, err := analyze(command.Args())
+	if err != nil {
+		return err
+	}
+
+	results, err := collectResults(graph)
+	if err != nil {
+		return err
+	}
+
+	suppressions := lo.FlatMap(results, func(result *forceloader.Result, _ int) []forceloader.Suppression {
+		return result.Suppressions
+	})
+	sort.Slice(suppressions, func(i, j int) bool {
+		x, y := suppressions[i].Position, suppressions[j].Position
+
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+
+		return x.Line < y.Line
+	})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POSITION\tDIRECTIVE\tSCOPE\tOWNER\tUNTIL\tREASON")
+
+	lo.ForEach(suppressions, func(suppression forceloader.Suppression, _ int) {
+		filename, err := filepath.Rel(wd, suppression.Position.Filename)
+		if err != nil {
+			filename = suppression.Position.Filename
+		}
+
+		until := "-"
+		if !suppression.Until.IsZero() {
+			until = suppression.Until.Format(time.DateOnly)
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s:%d\t%s\t%s\t%s\t%s\t%s\n",
+			filename,
+			suppression.Position.Line,
+			suppression.Directive,
+			suppression.Scope,
+			lo.Ternary(suppression.Owner == "", "-", suppression.Owner),
+			until,
+			suppression.Reason,
+		)
+	})
+
+	return w.Flush()
+}
@@ -0,0 +1,380 @@
+package forceloader
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the analyzer, read from the file given by -config.
+// Flags set on the command line take precedence over the file.
+type Config struct {
+	ResolverStruct        string   `yaml:"resolverStruct"`
+	RestrictedPackages    []string `yaml:"restrictedPackages"`
+	IgnoreResolverStructs []string `yaml:"ignoreResolverStructs"`
+	// Enable and Disable hold rule IDs or names. Disable wins over Enable.
+	Enable  []string `yaml:"enable"`
+	Disable []string `yaml:"disable"`
+	// Severities override the severity of rules, by rule ID or name.
+	Severities map[string]string `yaml:"severity"`
+	// Warnings tells how diagnostics below error are handled: report, print or drop.
+	Warnings string `yaml:"warnings"`
+	// Kinds sets the kind of resolver types like "a/graph.viewerResolver",
+	// for those not named like the gqlgen root resolvers.
+	Kinds map[string]string `yaml:"kinds"`
+	// Policies override the policies of the kinds of resolvers.
+	Policies map[string]Policy `yaml:"policies"`
+	// Boundaries are checked like root resolvers, for code outside of GraphQL.
+	Boundaries []Boundary `yaml:"boundaries"`
+	// Framework tells how resolvers are found: gqlgen (the default) or graph-gophers.
+	Framework string `yaml:"framework"`
+	// Schema holds glob patterns of the GraphQL schema files, relative to the configuration file.
+	Schema []string `yaml:"schema"`
+	// Gqlgen is the gqlgen configuration file, relative to the configuration file. The model methods
+	// its autobind and models bind to fields of the schema are checked as field resolvers.
+	Gqlgen string `yaml:"gqlgen"`
+
+	enabled    map[string]bool
+	severities map[string]Severity
+	kinds      map[string]resolverKind
+	policies   map[resolverKind]policy
+	schema     *graphqlSchema
+	bindings   modelBindings
+}
+
+// LoadConfig reads the configuration file, if any, and applies the flags over it.
+func LoadConfig() (*Config, error) {
+	config := &Config{}
+
+	if *configFile != "" {
+		src, err := os.ReadFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(src))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", *configFile, err)
+		}
+
+		config.Schema = lo.Map(config.Schema, func(pattern string, _ int) string {
+			if filepath.IsAbs(pattern) {
+				return pattern
+			}
+
+			return filepath.Join(filepath.Dir(*configFile), pattern)
+		})
+
+		if config.Gqlgen != "" && !filepath.IsAbs(config.Gqlgen) {
+			config.Gqlgen = filepath.Join(filepath.Dir(*configFile), config.Gqlgen)
+		}
+	}
+
+	if *framework != "" {
+		config.Framework = *framework
+	}
+
+	if config.Framework == "" {
+		config.Framework = frameworkGqlgen
+	}
+
+	if *schemaFiles != "" {
+		config.Schema = splitList(*schemaFiles)
+	}
+
+	if *gqlgenFile != "" {
+		config.Gqlgen = *gqlgenFile
+	}
+
+	if *resolverStruct != "" {
+		config.ResolverStruct = *resolverStruct
+	}
+
+	if *restrictedPackages != "" {
+		config.RestrictedPackages = splitList(*restrictedPackages)
+	}
+
+	if *ignoreResolverStructs != "" {
+		config.IgnoreResolverStructs = splitList(*ignoreResolverStructs)
+	}
+
+	if *enable != "" {
+		config.Enable = append(config.Enable, splitList(*enable)...)
+	}
+
+	if *disable != "" {
+		config.Disable = append(config.Disable, splitList(*disable)...)
+	}
+
+	if *severity != "" {
+		if config.Severities == nil {
+			config.Severities = make(map[string]string)
+		}
+
+		for _, pair := range splitList(*severity) {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("severity %q is not rule=severity", pair)
+			}
+
+			config.Severities[strings.TrimSpace(key)] = value
+		}
+	}
+
+	config.ResolverStruct = strings.TrimSpace(config.ResolverStruct)
+	config.RestrictedPackages = compactList(config.RestrictedPackages)
+	config.IgnoreResolverStructs = compactList(config.IgnoreResolverStructs)
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if *warnings != "" {
+		config.Warnings = *warnings
+	}
+
+	if config.Warnings == "" {
+		config.Warnings = warningsPrint
+	}
+
+	if !lo.Contains([]string{warningsReport, warningsPrint, warningsDrop}, config.Warnings) {
+		return nil, fmt.Errorf("unknown warnings %q, must be one of report, print or drop", config.Warnings)
+	}
+
+	if *reportEscapes {
+		config.Enable = append(config.Enable, RestrictedEscape.ID)
+	}
+
+	config.enabled = make(map[string]bool, len(Rules))
+	config.severities = make(map[string]Severity, len(Rules))
+
+	lo.ForEach(Rules, func(rule Rule, _ int) {
+		config.enabled[rule.ID] = !rule.Disabled
+		config.severities[rule.ID] = rule.Severity
+	})
+
+	setEnabled := func(keys []string, enabled bool) error {
+		for _, key := range keys {
+			rule, err := LookupRule(strings.TrimSpace(key))
+			if err != nil {
+				return err
+			}
+
+			config.enabled[rule.ID] = enabled
+		}
+
+		return nil
+	}
+
+	if err := setEnabled(config.Enable, true); err != nil {
+		return nil, err
+	}
+
+	if err := setEnabled(config.Disable, false); err != nil {
+		return nil, err
+	}
+
+	for key, value := range config.Severities {
+		rule, err := LookupRule(key)
+		if err != nil {
+			return nil, err
+		}
+
+		severity, err := parseSeverity(value)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+
+		config.severities[rule.ID] = severity
+	}
+
+	config.kinds = make(map[string]resolverKind, len(config.Kinds))
+
+	for name, value := range config.Kinds {
+		if err := validateTypeName(name); err != nil {
+			return nil, fmt.Errorf("invalid kinds: %w", err)
+		}
+
+		kind, err := parseResolverKind(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kinds: %s: %w", name, err)
+		}
+
+		config.kinds[name] = kind
+	}
+
+	config.policies = make(map[resolverKind]policy, len(defaultPolicies))
+
+	for kind, policy := range defaultPolicies {
+		config.policies[kind] = policy
+	}
+
+	for key, policy := range config.Policies {
+		kind, err := parseResolverKind(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policies: %w", err)
+		}
+
+		config.policies[kind] = policy.apply(config.policies[kind])
+	}
+
+	if len(config.Schema) > 0 {
+		schema, err := loadSchema(config.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		config.schema = schema
+	}
+
+	if config.Gqlgen != "" {
+		bindings, err := loadModelBindings(config.Gqlgen)
+		if err != nil {
+			return nil, err
+		}
+
+		config.bindings = bindings
+	}
+
+	return config, nil
+}
+
+// loadedConfig caches the configuration across the packages analysed by the process,
+// so that go vet does not read, parse and validate the files once per package.
+var loadedConfig struct {
+	sync.Mutex
+	key    string
+	config *Config
+	err    error
+}
+
+// getConfig returns the configuration LoadConfig returns, loading it again only when the flags change.
+func getConfig() (*Config, error) {
+	key := strings.Join([]string{
+		*configFile, *framework, *schemaFiles, *gqlgenFile, *resolverStruct, *restrictedPackages, *ignoreResolverStructs,
+		*enable, *disable, *severity, *warnings, strconv.FormatBool(*reportEscapes),
+	}, "\x00")
+
+	loadedConfig.Lock()
+	defer loadedConfig.Unlock()
+
+	if loadedConfig.config == nil && loadedConfig.err == nil || loadedConfig.key != key {
+		loadedConfig.key = key
+		loadedConfig.config, loadedConfig.err = LoadConfig()
+	}
+
+	return loadedConfig.config, loadedConfig.err
+}
+
+// validate checks that the names in the configuration parse.
+// Whether they match anything is only known after the analysis.
+func (c *Config) validate() error {
+	if c.ResolverStruct != "" {
+		if err := validateTypeName(c.ResolverStruct); err != nil {
+			return fmt.Errorf("invalid resolverStruct: %w", err)
+		}
+	}
+
+	for _, name := range c.IgnoreResolverStructs {
+		if err := validateTypeName(name); err != nil {
+			return fmt.Errorf("invalid ignoreResolverStructs: %w", err)
+		}
+	}
+
+	for _, path := range c.RestrictedPackages {
+		if err := module.CheckImportPath(path); err != nil {
+			return fmt.Errorf("invalid restrictedPackages: %w", err)
+		}
+	}
+
+	for _, boundary := range c.Boundaries {
+		if err := boundary.validate(); err != nil {
+			return fmt.Errorf("invalid boundaries: %w", err)
+		}
+	}
+
+	if !lo.Contains([]string{frameworkGqlgen, frameworkGraphGophers}, c.Framework) {
+		return fmt.Errorf("unknown framework %q, must be one of gqlgen or graph-gophers", c.Framework)
+	}
+
+	return nil
+}
+
+// validateTypeName checks that name is a type name like "a/graph.Resolver".
+func validateTypeName(name string) error {
+	i := strings.LastIndex(name, ".")
+	if i < 0 || strings.Contains(name[i+1:], "/") {
+		return fmt.Errorf("%q must be a package path and a type name like a/graph.Resolver", name)
+	}
+
+	if err := module.CheckImportPath(name[:i]); err != nil {
+		return fmt.Errorf("%q: %w", name, err)
+	}
+
+	if !token.IsIdentifier(name[i+1:]) {
+		return fmt.Errorf("%q: %q is not a type name", name, name[i+1:])
+	}
+
+	return nil
+}
+
+// splitList splits a comma separated flag value, dropping empty items.
+func splitList(s string) []string {
+	return compactList(strings.Split(s, ","))
+}
+
+func compactList(items []string) []string {
+	return lo.Compact(lo.Map(items, func(item string, _ int) string {
+		return strings.TrimSpace(item)
+	}))
+}
+
+// IsEnabled reports whether the diagnostics of rule are reported.
+func (c *Config) IsEnabled(rule Rule) bool {
+	return c.enabled[rule.ID]
+}
+
+// Severity returns the severity of the diagnostics of rule.
+func (c *Config) Severity(rule Rule) Severity {
+	return c.severities[rule.ID]
+}
+
+// report reports a diagnostic of rule, unless the rule is disabled.
+// Diagnostics below error are printed or dropped instead when the configuration says so.
+func (c *Config) report(
+	pass *analysis.Pass,
+	rule Rule,
+	diagnostic analysis.Diagnostic,
+) {
+	if !c.IsEnabled(rule) {
+		return
+	}
+
+	if severity := c.Severity(rule); severity.Less(SeverityError) {
+		switch c.Warnings {
+		case warningsDrop:
+			return
+		case warningsPrint:
+			fmt.Fprintf(stderr, "%s: %s: %s (%s)\n", pass.Fset.Position(diagnostic.Pos), severity, diagnostic.Message, rule.ID)
+
+			return
+		}
+	}
+
+	diagnostic.Category = rule.ID
+	diagnostic.URL = rule.URL()
+
+	pass.Report(diagnostic)
+}
@@ -0,0 +1,69 @@
+package forceloader
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// eagerField is a field of a model filled in a loop with the result of a restricted call.
+type eagerField struct {
+	// Field is the schema field bound to the model field, like "Todo.user".
+	Field  string
+	Symbol string
+}
+
+// getEagerField reports whether inst stores the result of a restricted call, made in a loop of the resolver,
+// into a field of a model that gqlgen binds to a field of the schema. Such a field is better resolved
+// by a field resolver through a loader, forced with @goField(forceResolver: true).
+func getEagerField(
+	inst ssa.Instruction,
+	scope *resolverScope,
+	bindings modelBindings,
+	restrictedPackages []string,
+	helpers map[*ssa.Function]string,
+) (eagerField, bool) {
+	store, ok := inst.(*ssa.Store)
+	if !ok {
+		return eagerField{}, false
+	}
+
+	addr, ok := store.Addr.(*ssa.FieldAddr)
+	if !ok {
+		return eagerField{}, false
+	}
+
+	value := store.Val
+	if extract, ok := value.(*ssa.Extract); ok {
+		value = extract.Tuple
+	}
+
+	call, ok := value.(*ssa.Call)
+	if !ok || !scope.inLoop && !scope.loops[call.Block()] {
+		return eagerField{}, false
+	}
+
+	target, ok := getRestrictedTarget(call, restrictedPackages)
+	if !ok {
+		if target, ok = getHelperTarget(call, helpers); !ok {
+			return eagerField{}, false
+		}
+	}
+
+	named, ok := derefNamed(addr.X.Type())
+	if !ok {
+		return eagerField{}, false
+	}
+
+	str, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return eagerField{}, false
+	}
+
+	field := bindings.getField(named, str.Field(addr.Field).Name())
+	if field == "" {
+		return eagerField{}, false
+	}
+
+	return eagerField{Field: field, Symbol: target.Symbol}, true
+}
@@ -0,0 +1,92 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/ssa"
+)
+
+// getRestrictedType reports whether v holds a value of a type of a restricted package,
+// looking through conversions to interfaces.
+func getRestrictedType(
+	v ssa.Value,
+	restrictedPackages []string,
+) (*types.Named, bool) {
+	if mi, ok := v.(*ssa.MakeInterface); ok {
+		v = mi.X
+	}
+
+	named, ok := derefNamed(v.Type())
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+
+	return named, lo.Contains(restrictedPackages, named.Obj().Pkg().Path())
+}
+
+// getEscapeTargets returns the values of restricted types that inst lets escape the resolver:
+// passed as an argument, returned, stored in a struct field or sent on a channel.
+func getEscapeTargets(
+	inst ssa.Instruction,
+	restrictedPackages []string,
+) []restrictedTarget {
+	escape := func(v ssa.Value, via string) []restrictedTarget {
+		named, ok := getRestrictedType(v, restrictedPackages)
+		if !ok {
+			return nil
+		}
+
+		return []restrictedTarget{{Pos: inst.Pos(), Symbol: qualifiedName(named.Obj()), Via: via}}
+	}
+
+	switch inst := inst.(type) {
+	case ssa.CallInstruction:
+		common := inst.Common()
+
+		// Calls into restricted packages are the concern of the call rule.
+		if _, ok := getRestrictedType(common.Value, restrictedPackages); ok && common.IsInvoke() {
+			return nil
+		}
+
+		callee := common.StaticCallee()
+		if callee != nil && callee.Pkg != nil && lo.Contains(restrictedPackages, callee.Pkg.Pkg.Path()) {
+			return nil
+		}
+
+		name := common.Value.Name()
+		if callee != nil {
+			name = callee.String()
+		}
+
+		return lo.FlatMap(common.Args, func(arg ssa.Value, _ int) []restrictedTarget {
+			return escape(arg, fmt.Sprintf("argument of %s", name))
+		})
+	case *ssa.Return:
+		return lo.FlatMap(inst.Results, func(result ssa.Value, _ int) []restrictedTarget {
+			return escape(result, "return value")
+		})
+	case *ssa.Store:
+		fieldAddr, ok := inst.Addr.(*ssa.FieldAddr)
+		if !ok {
+			return nil
+		}
+
+		named, ok := derefNamed(fieldAddr.X.Type())
+		if !ok {
+			return escape(inst.Val, "struct field")
+		}
+
+		str, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return escape(inst.Val, "struct field")
+		}
+
+		return escape(inst.Val, fmt.Sprintf("struct field %s.%s", qualifiedName(named.Obj()), str.Field(fieldAddr.Field).Name()))
+	case *ssa.Send:
+		return escape(inst.X, "channel send")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package forceloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/token"
+)
+
+// Finding is a violation found in a resolver: a restricted call, a restricted value escaping,
+// or a write to shared state.
+type Finding struct {
+	// Rule is the rule the finding is reported by.
+	Rule     Rule
+	Package  string
+	Resolver string
+	Symbol   string
+	Caller   string
+	Snippet  string
+	// Via tells how a value of a restricted package escapes the resolver, and is empty for calls.
+	Via string
+	// Limit tells which limit of the policy of the resolver a call exceeds,
+	// and is empty when the policy allows no calls.
+	Limit string
+	// Through is the helper of the package making the restricted call, and is empty for direct calls.
+	Through string
+	// Field is the schema field a root resolver fetches for each item, like "Todo.user", and is empty for other rules.
+	Field string
+	Pos   token.Pos
+}
+
+// The limits of the policy of a resolver.
+const (
+	limitCalls     = "calls"
+	limitLoop      = "loop"
+	limitGoroutine = "goroutine"
+	// limitGo is the goroutine limit, exceeded by a call made by a go statement of the resolver.
+	limitGo = "go"
+	limitPure      = "pure"
+)
+
+func (f Finding) Message() string {
+	if f.Rule == SharedWrite {
+		return fmt.Sprintf(
+			"%s writes to %s, which field resolvers running concurrently share; guard the write with a lock",
+			f.Resolver,
+			f.Symbol,
+		)
+	}
+
+	if f.Rule == EagerField {
+		return fmt.Sprintf(
+			"%s is fetched for each item by %s with %s; resolve it with a loader in a field resolver, forced with @goField(forceResolver: true)",
+			f.Field,
+			f.Resolver,
+			f.Symbol,
+		)
+	}
+
+	if f.Via != "" {
+		return fmt.Sprintf("%s escapes %s via %s", f.Symbol, f.Resolver, f.Via)
+	}
+
+	symbol := f.Symbol
+	if f.Through != "" {
+		symbol = fmt.Sprintf("%s through %s", f.Symbol, f.Through)
+	}
+
+	switch f.Limit {
+	case limitCalls:
+		return fmt.Sprintf("%s (%s) exceeds the restricted calls allowed in %s", f.Caller, symbol, f.Resolver)
+	case limitLoop:
+		return fmt.Sprintf("%s (%s) cannot be used in a loop in %s", f.Caller, symbol, f.Resolver)
+	case limitGoroutine:
+		return fmt.Sprintf("%s (%s) cannot be used in %s, which runs in a goroutine", f.Caller, symbol, f.Resolver)
+	case limitGo:
+		return fmt.Sprintf("%s (%s) cannot be started in a goroutine by %s", f.Caller, symbol, f.Resolver)
+	case limitPure:
+		return fmt.Sprintf("%s (%s) cannot be used in %s, which must be pure", f.Caller, symbol, f.Resolver)
+	default:
+		return fmt.Sprintf("%s (%s) cannot be used in %s", f.Caller, symbol, f.Resolver)
+	}
+}
+
+// getTargetRule returns the rule a restricted target escaping via via, or exceeding limit, is reported by.
+func getTargetRule(via string, limit string) Rule {
+	if via != "" {
+		return RestrictedEscape
+	}
+
+	switch limit {
+	case limitLoop:
+		return RestrictedLoop
+	case limitPure:
+		return PureFunction
+	}
+
+	return RestrictedCall
+}
+
+// Fingerprint identifies the finding independently of its line number.
+func (f Finding) Fingerprint() string {
+	key := f.Resolver + "\x00" + f.Symbol + "\x00" + f.Snippet
+	if f.Via != "" {
+		key += "\x00" + f.Via
+	}
+
+	if f.Field != "" {
+		key += "\x00" + f.Field
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:8])
+}
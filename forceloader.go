@@ -1,37 +1,58 @@
 package forceloader
 
 import (
-	"bytes"
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/printer"
+	"go/scanner"
 	"go/token"
 	"go/types"
+	"reflect"
 	"strings"
 
 	"github.com/samber/lo"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/ssa"
 )
 
 const name = "forceloader"
 
 var Analyzer = &analysis.Analyzer{
-	Name: name,
-	Doc:  "forceloader is testing tool for dataloader",
-	Run:  run,
-	Requires: []*analysis.Analyzer{
-		buildssa.Analyzer,
-	},
+	Name:       name,
+	Doc:        "forceloader is testing tool for dataloader",
+	Run:        run,
+	ResultType: reflect.TypeOf(&Result{}),
+	FactTypes:  []analysis.Fact{new(restrictedHelperFact)},
+}
+
+// Result is the result of the analyzer for a package.
+type Result struct {
+	Findings []Finding
+	// Suppressions are the directives that are neither malformed nor expired.
+	Suppressions []Suppression
+	// ResolverStructFound tells whether the resolver struct is declared in the package or in its imports.
+	ResolverStructFound bool
+	// RestrictedImports are the restricted packages the package imports, directly or not.
+	RestrictedImports []string
 }
 
 var (
 	resolverStruct        *string
 	restrictedPackages    *string
 	ignoreResolverStructs *string
+	baseline              *string
+	requireNolintReason   *bool
+	reportEscapes         *bool
+	configFile            *string
+	enable                *string
+	disable               *string
+	severity              *string
+	warnings              *string
+	framework             *string
+	schemaFiles           *string
+	gqlgenFile            *string
 )
 
 //nolint: gochecknoinits
@@ -41,6 +62,17 @@ func init() {
 	resolverStruct = command.String("resolverStruct", "", "")
 	restrictedPackages = command.String("restrictedPackages", "", "")
 	ignoreResolverStructs = command.String("ignoreResolverStructs", "", "")
+	baseline = command.String("baseline", "", "report only violations not recorded in the baseline file")
+	reportEscapes = command.Bool("reportEscapes", false, "same as -enable=FL002")
+	requireNolintReason = command.Bool("requireNolintReason", false, "require nolint:forceloader directives to have a reason")
+	configFile = command.String("config", "", "read the configuration from the YAML file")
+	enable = command.String("enable", "", "comma separated IDs or names of rules to enable")
+	disable = command.String("disable", "", "comma separated IDs or names of rules to disable")
+	severity = command.String("severity", "", "comma separated severities of rules, e.g. FL003=warning,FL006=info")
+	warnings = command.String("warnings", "", "how warning and info diagnostics are handled: report, print (default) or drop")
+	framework = command.String("framework", "", "how resolvers are found: gqlgen (default) or graph-gophers")
+	schemaFiles = command.String("schema", "", "comma separated glob patterns of the GraphQL schema files")
+	gqlgenFile = command.String("gqlgen", "", "check the model methods bound by the gqlgen configuration file")
 
 	Analyzer.Flags = *command
 }
@@ -50,250 +82,634 @@ var (
 	restrictedFields []string
 )
 
+// buildSSA is replaced in tests.
+var buildSSA = buildssa.Analyzer.Run
+
 func run(pass *analysis.Pass) (any, error) {
-	_ssa, ok := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
-	if !ok {
-		return false, fmt.Errorf("failed to initialized")
+	config, err := getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+	usedNolints := make(map[token.Pos]bool)
+	index := newPassIndex(pass)
+	ignoreScopes := getIgnoreScopes(pass)
+	annotations := getResolverAnnotations(pass)
+	resolverType := lookupTypeName(pass.Pkg, config.ResolverStruct)
+
+	reportMisconfiguration(pass, config, resolverType)
+
+	boundaries := newBoundaryMatcher(pass, config.Boundaries, annotations)
+	srcFuncs := make([]*ssa.Function, 0)
+
+	var (
+		gophers        *gophersResolvers
+		isResolverType func(named *types.Named) bool
+	)
+
+	switch {
+	case config.Framework == frameworkGraphGophers:
+		gophers = newGophersResolvers(resolverType, config.schema, config.RestrictedPackages)
+		isResolverType = gophers.Contains
+	case resolverType != nil || config.bindings != nil || len(config.IgnoreResolverStructs) > 0:
+		isResolverType = func(named *types.Named) bool {
+			return resolverType != nil && embedsType(named, resolverType) || config.bindings.Contains(named) ||
+				lo.Contains(config.IgnoreResolverStructs, qualifiedName(named.Obj()))
+		}
+	}
+
+	graphqlGoFields := getGraphqlGoFields(pass)
+
+	// SSA is built lazily, as it is the main cost and most packages have no resolvers.
+	// The checks below only look at the AST and types.Info, and SSA is built for the packages passing any of them.
+	if mayContainResolvers(pass, isResolverType, annotations) || !boundaries.IsEmpty() || len(graphqlGoFields) > 0 ||
+		mayContainMiddlewares(pass) || mayContainPureFuncs(pass) || mayContainHelpers(pass, config.RestrictedPackages) {
+		_ssa, err := buildSSA(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		result, ok := _ssa.(*buildssa.SSA)
+		if !ok {
+			return nil, fmt.Errorf("failed to initialized")
+		}
+
+		srcFuncs = result.SrcFuncs
 	}
 
-	restrictedPackages := strings.Split(*restrictedPackages, ",")
+	resolverFuncs := getResolverFuncs(srcFuncs, resolverType, gophers, graphqlGoFields, config, annotations, boundaries)
+	helpers := getRestrictedHelpers(srcFuncs, config.RestrictedPackages, func(fn *ssa.Function) string {
+		return importHelperSymbol(pass, fn)
+	})
+
+	// Resolvers among the helpers are checked on their own.
+	for fn := range resolverFuncs {
+		delete(helpers, fn)
+	}
+
+	exportHelpers(pass, helpers)
+
+	// calls counts the restricted calls of each resolver on each path, against the calls its policy allows.
+	calls := make(pathCalls)
+
+	// isSuppressed reports whether pos is in an ignored scope or on a line with a nolint directive, which is then in use.
+	isSuppressed := func(pos token.Pos) bool {
+		isIgnored := lo.SomeBy(ignoreScopes, func(scope ignoreScope) bool {
+			return scope.Contains(pos)
+		})
+		if isIgnored {
+			return true
+		}
+
+		file := index.File(pos)
+		if file == nil {
+			return true
+		}
 
-	lo.ForEach(_ssa.SrcFuncs, func(fn *ssa.Function, _ int) {
-		_isResolver := isResolver(fn)
-		if !_isResolver {
+		nolintPos, _isNolint := getNolint(file, pos)
+		if _isNolint {
+			usedNolints[nolintPos] = true
+		}
+
+		return _isNolint
+	}
+
+	lo.ForEach(srcFuncs, func(fn *ssa.Function, _ int) {
+		scope, ok := resolverFuncs[fn]
+		if !ok {
 			return
 		}
 
-		lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+		policy := config.policies[scope.kind]
+
+		// Blocks are visited in dominator order, so that the calls on the path to a block are counted before it.
+		lo.ForEach(fn.DomPreorder(), func(block *ssa.BasicBlock, _ int) {
 			lo.ForEach(block.Instrs, func(inst ssa.Instruction, _ int) {
-				call, ok := inst.(*ssa.Call)
-				if !ok {
-					return
-				}
+				targets := make([]restrictedTarget, 0)
 
-				named, ok := call.Call.Value.Type().(*types.Named)
-				if !ok {
-					return
+				if target, ok := getRestrictedTarget(inst, config.RestrictedPackages); ok {
+					targets = append(targets, target)
 				}
 
-				isTarget := lo.Contains(restrictedPackages, named.Obj().Pkg().Path())
-				if !isTarget {
-					return
+				// A helper making restricted calls is only a concern when it is called per item.
+				if target, ok := getHelperTarget(inst, helpers); ok && (scope.inLoop || scope.loops[block] || scope.pure) {
+					targets = append(targets, target)
 				}
 
-				nodePos := pass.Fset.Position(call.Pos())
-
-				astFile, err := parser.ParseFile(pass.Fset, nodePos.Filename, nil, parser.ParseComments)
-				if err != nil {
-					return
+				targets = append(targets, getEscapeTargets(inst, config.RestrictedPackages)...)
+
+				// Only the roots fetch the fields of the models they return, field resolvers fetch their own.
+				if scope.kind != kindField && scope.kind != kindBoundary {
+					eager, ok := getEagerField(inst, scope, config.bindings, config.RestrictedPackages, helpers)
+					if ok && !isSuppressed(inst.Pos()) && config.IsEnabled(EagerField) {
+						findings = append(findings, Finding{
+							Rule:     EagerField,
+							Package:  pass.Pkg.Path(),
+							Resolver: lo.Ternary(scope.name != "", scope.name, scope.root.String()),
+							Symbol:   eager.Symbol,
+							Snippet:  normalizeSnippet([]byte(index.File(inst.Pos()).Line(inst.Pos()))),
+							Field:    eager.Field,
+							Pos:      inst.Pos(),
+						})
+					}
 				}
 
-				_isNolint := isNolint(pass, astFile, nodePos)
-				if _isNolint {
-					return
+				// gqlgen runs field resolvers concurrently, so the state they share must not be written without a lock.
+				if scope.kind == kindField && !scope.isRoot && !scope.pure {
+					if write, ok := getSharedWrite(fn, scope, inst); ok && !isSuppressed(write.Pos) && config.IsEnabled(SharedWrite) {
+						findings = append(findings, Finding{
+							Rule:     SharedWrite,
+							Package:  pass.Pkg.Path(),
+							Resolver: lo.Ternary(scope.name != "", scope.name, fn.String()),
+							Symbol:   write.Target,
+							Snippet:  normalizeSnippet([]byte(index.File(write.Pos).Line(write.Pos))),
+							Pos:      write.Pos,
+						})
+					}
 				}
 
-				pass.Report(analysis.Diagnostic{
-					Pos:     call.Call.Pos(),
-					Message: fmt.Sprintf("%s cannot be used in %s", getSourceCaller(pass, call, astFile, nodePos), fn.String()),
+				lo.ForEach(targets, func(target restrictedTarget, _ int) {
+					if isSuppressed(target.Pos) {
+						return
+					}
+
+					file := index.File(target.Pos)
+
+					limit, ok := getPolicyLimit(policy, scope, block, target, calls)
+					if !ok {
+						return
+					}
+
+					finding := Finding{
+						Rule:     getTargetRule(target.Via, limit),
+						Package:  pass.Pkg.Path(),
+						Resolver: lo.Ternary(scope.name != "", scope.name, fn.String()),
+						Symbol:   target.Symbol,
+						Caller:   getSourceCaller(file, target.Pos, inst.String()),
+						Snippet:  normalizeSnippet([]byte(file.Line(target.Pos))),
+						Via:      target.Via,
+						Limit:    limit,
+						Through:  target.Through,
+						Pos:      target.Pos,
+					}
+
+					// Targets of disabled rules are still looked for, so that their nolint directives stay in use.
+					if config.IsEnabled(finding.Rule) {
+						findings = append(findings, finding)
+					}
 				})
 			})
 		})
 	})
 
-	return nil, nil
+	if err := report(pass, config, findings); err != nil {
+		return nil, err
+	}
+
+	reportNolints(pass, config, usedNolints)
+
+	suppressions := getSuppressions(pass)
+	reportIgnoreDirectives(pass, config, suppressions)
+
+	return &Result{
+		Findings: findings,
+		Suppressions: lo.Filter(suppressions, func(suppression Suppression, _ int) bool {
+			return suppression.err == nil && !suppression.expired
+		}),
+		ResolverStructFound: resolverType != nil,
+		RestrictedImports: lo.Filter(config.RestrictedPackages, func(path string, _ int) bool {
+			return importsPackage(pass.Pkg, path)
+		}),
+	}, nil
 }
 
-func getSourceCaller(
+func report(
 	pass *analysis.Pass,
-	call *ssa.Call,
-	astFile *ast.File,
-	nodePos token.Position,
-) string {
-	text := call.String()
-
-	var prev ast.Node
-
-	ast.Inspect(astFile, func(n ast.Node) bool {
-		if n == nil {
-			return true
-		}
+	config *Config,
+	findings []Finding,
+) error {
+	if *baseline == "" {
+		lo.ForEach(findings, func(finding Finding, _ int) {
+			config.report(pass, finding.Rule, analysis.Diagnostic{
+				Pos:     finding.Pos,
+				Message: finding.Message(),
+			})
+		})
 
-		if prev == nil {
-			prev = n
+		return nil
+	}
 
-			return true
-		}
+	b, err := LoadBaseline(*baseline)
+	if err != nil {
+		return err
+	}
 
-		startOffset := pass.Fset.Position(prev.Pos()).Offset
-		endOffset := pass.Fset.Position(n.Pos()).Offset
+	newFindings, staleEntries := b.Filter(pass.Pkg.Path(), findings)
 
-		if startOffset <= nodePos.Offset && nodePos.Offset <= endOffset {
-			ast.Inspect(prev, func(n ast.Node) bool {
-				selector, ok := n.(*ast.SelectorExpr)
-				if !ok {
-					return true
-				}
+	lo.ForEach(newFindings, func(finding Finding, _ int) {
+		config.report(pass, finding.Rule, analysis.Diagnostic{
+			Pos:     finding.Pos,
+			Message: finding.Message(),
+		})
+	})
 
-				var buf bytes.Buffer
+	if len(pass.Files) == 0 {
+		return nil
+	}
 
-				if err := printer.Fprint(&buf, token.NewFileSet(), selector); err != nil {
-					panic(err)
-				}
+	lo.ForEach(staleEntries, func(entry BaselineEntry, _ int) {
+		config.report(pass, StaleBaseline, analysis.Diagnostic{
+			Pos: pass.Files[0].Package,
+			Message: fmt.Sprintf(
+				"stale baseline entry %s: %s in %s no longer matches",
+				entry.Fingerprint,
+				entry.Snippet,
+				entry.Resolver,
+			),
+		})
+	})
 
-				text = buf.String()
+	return nil
+}
 
-				return false
+func reportNolints(
+	pass *analysis.Pass,
+	config *Config,
+	usedNolints map[token.Pos]bool,
+) {
+	lo.ForEach(getNolintDirectives(pass), func(directive nolintDirective, _ int) {
+		if *requireNolintReason && directive.Reason == "" {
+			config.report(pass, InvalidSuppression, analysis.Diagnostic{
+				Pos:     directive.Pos,
+				Message: "nolint:forceloader directive requires a reason, e.g. //nolint:forceloader // reason",
 			})
+		}
 
-			return false
+		if !usedNolints[directive.Pos] {
+			config.report(pass, UnusedSuppression, analysis.Diagnostic{
+				Pos:     directive.Pos,
+				Message: "unused nolint:forceloader directive",
+			})
 		}
+	})
+}
+
+// reportMisconfiguration reports the resolver struct missing from the package it names.
+// Names that match no package at all are only known once every package is analysed.
+func reportMisconfiguration(
+	pass *analysis.Pass,
+	config *Config,
+	resolverType *types.TypeName,
+) {
+	if resolverType != nil || config.ResolverStruct == "" || len(pass.Files) == 0 {
+		return
+	}
+
+	path := config.ResolverStruct[:strings.LastIndex(config.ResolverStruct, ".")]
+	if pass.Pkg.Path() != path {
+		return
+	}
 
-		return true
+	config.report(pass, Misconfiguration, analysis.Diagnostic{
+		Pos:     pass.Files[0].Package,
+		Message: fmt.Sprintf("resolverStruct %s is not declared in %s", config.ResolverStruct, path),
 	})
+}
 
-	return text
+// restrictedTarget is a use of a method or a value of a restricted package.
+type restrictedTarget struct {
+	Pos    token.Pos
+	Symbol string
+	// Via tells how a value escapes the resolver, and is empty for calls.
+	Via string
+	// Through is the helper of the package making the call, and is empty for direct calls.
+	Through string
+	// Go tells that the call is made by a go statement, so that it runs in a goroutine.
+	Go bool
 }
 
-func isResolver(
-	fn *ssa.Function,
-) bool {
-	ptrs := make([]*types.Pointer, 0, len(fn.FreeVars)+len(fn.Params))
+// getRestrictedTarget reports whether inst calls a method of a restricted package, in a plain, go or defer statement,
+// or takes it as a method value such as `f := r.UseCase.Fuga`.
+func getRestrictedTarget(
+	inst ssa.Instruction,
+	restrictedPackages []string,
+) (restrictedTarget, bool) {
+	switch inst := inst.(type) {
+	case ssa.CallInstruction:
+		call := inst.Common()
+
+		// Universe types such as error have no package.
+		named, ok := call.Value.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return restrictedTarget{}, false
+		}
 
-	lo.ForEach(fn.FreeVars, func(param *ssa.FreeVar, _ int) {
-		ptr, ok := param.Type().(*types.Pointer)
-		if !ok {
-			return
+		isTarget := lo.Contains(restrictedPackages, named.Obj().Pkg().Path())
+		if !isTarget {
+			return restrictedTarget{}, false
 		}
 
-		ptr2, ok := ptr.Elem().(*types.Pointer)
-		if !ok {
-			return
+		symbol := call.String()
+		if call.Method != nil {
+			symbol = fmt.Sprintf("%s.%s", qualifiedName(named.Obj()), call.Method.Name())
 		}
 
-		ptrs = append(ptrs, ptr2)
-	})
+		_, isGo := inst.(*ssa.Go)
 
-	lo.ForEach(fn.Params, func(param *ssa.Parameter, _ int) {
-		ptr, ok := param.Type().(*types.Pointer)
+		return restrictedTarget{Pos: call.Pos(), Symbol: symbol, Go: isGo}, true
+	case *ssa.MakeClosure:
+		// A method value is a closure over a bound method wrapper.
+		fn, ok := inst.Fn.(*ssa.Function)
+		if !ok || !strings.HasSuffix(fn.Name(), "$bound") {
+			return restrictedTarget{}, false
+		}
+
+		method, ok := fn.Object().(*types.Func)
 		if !ok {
-			return
+			return restrictedTarget{}, false
 		}
 
-		ptrs = append(ptrs, ptr)
-	})
+		sig, ok := method.Type().(*types.Signature)
+		if !ok || sig.Recv() == nil {
+			return restrictedTarget{}, false
+		}
 
-	return lo.SomeBy(ptrs, func(ptr *types.Pointer) bool {
-		named, ok := ptr.Elem().(*types.Named)
-		if !ok {
-			return false
+		named, ok := derefNamed(sig.Recv().Type())
+		if !ok || named.Obj().Pkg() == nil {
+			return restrictedTarget{}, false
 		}
 
-		str, ok := named.Underlying().(*types.Struct)
-		if !ok {
-			return false
+		isTarget := lo.Contains(restrictedPackages, named.Obj().Pkg().Path())
+		if !isTarget {
+			return restrictedTarget{}, false
 		}
 
-		ignoreResolverStructs := strings.Split(*ignoreResolverStructs, ",")
+		return restrictedTarget{
+			Pos:    inst.Pos(),
+			Symbol: fmt.Sprintf("%s.%s", qualifiedName(named.Obj()), method.Name()),
+		}, true
+	default:
+		return restrictedTarget{}, false
+	}
+}
 
-		isIgnored := lo.Contains(ignoreResolverStructs, named.Obj().Type().String())
-		if isIgnored {
-			return false
+// normalizeSnippet re-joins the tokens of src, dropping comments and
+// whitespace so that formatting and nolint directives do not change it.
+func normalizeSnippet(
+	src []byte,
+) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var (
+		s        scanner.Scanner
+		buf      strings.Builder
+		prevWord bool
+	)
+
+	s.Init(file, src, nil, 0)
+
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
 		}
 
-		vars := lo.Times(str.NumFields(), func(i int) *types.Var {
-			return str.Field(i)
-		})
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
 
-		return lo.SomeBy(vars, func(v *types.Var) bool {
-			if v.Embedded() {
-				str := strings.Replace(v.Type().String(), "*", "", -1)
+		if lit == "" {
+			lit = tok.String()
+		}
 
-				if str == *resolverStruct {
-					return true
-				}
+		isWord := tok.IsKeyword() || tok.IsLiteral()
+		if prevWord && isWord {
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteString(lit)
+		prevWord = isWord
+	}
+
+	return buf.String()
+}
+
+// getSourceCaller renders the function expression of the call, or the method value, at pos.
+func getSourceCaller(
+	file *fileIndex,
+	pos token.Pos,
+	fallback string,
+) string {
+	path, _ := astutil.PathEnclosingInterval(file.file, pos, pos)
+
+	for _, node := range path {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if node.Lparen == pos {
+				return types.ExprString(node.Fun)
 			}
+		case *ast.SelectorExpr:
+			return types.ExprString(node)
+		}
+	}
 
-			return false
-		})
-	})
+	return fallback
 }
 
-func isNolint(
-	pass *analysis.Pass,
-	astFile *ast.File,
-	nodePos token.Position,
-) bool {
-	comments := getCommentFromCall(pass, astFile, nodePos)
+// getPolicyLimit reports whether the restricted target found in block breaks the policy of the resolver,
+// and which limit of the policy it exceeds. Escapes are never allowed, roots are only checked for loops,
+// and pure functions allow no call at all.
+func getPolicyLimit(
+	policy policy,
+	scope *resolverScope,
+	block *ssa.BasicBlock,
+	target restrictedTarget,
+	calls pathCalls,
+) (string, bool) {
+	if target.Via != "" {
+		return "", !scope.isRoot
+	}
+
+	inLoop := scope.inLoop || scope.loops[block]
+
+	// Loops are checked first, as they are checked in roots too, even in the goroutines they start.
+	switch {
+	case scope.pure:
+		return limitPure, true
+	case inLoop && !policy.loops:
+		return limitLoop, true
+	case scope.inGoroutine:
+		return limitGoroutine, !scope.isRoot && !policy.goroutines
+	case target.Go:
+		return limitGo, !scope.isRoot && !policy.goroutines
+	case inLoop:
+		return limitLoop, false
+	case scope.isRoot:
+		return "", false
+	}
+
+	count := calls.add(block)
+
+	if policy.calls == 0 {
+		return "", true
+	}
+
+	return limitCalls, policy.calls >= 0 && count > policy.calls
+}
+
+// getResolverFuncs returns the resolvers among funcs, together with
+// the closures they declare at any depth, and where each of them runs.
+func getResolverFuncs(
+	funcs []*ssa.Function,
+	resolverType *types.TypeName,
+	gophers *gophersResolvers,
+	graphqlGoFields map[*ast.FuncLit]graphqlGoField,
+	config *Config,
+	annotations *resolverAnnotations,
+	boundaries *boundaryMatcher,
+) map[*ssa.Function]*resolverScope {
+	resolverFuncs := make(map[*ssa.Function]*resolverScope)
+	middlewares := getMiddlewareFuncs(funcs)
+	pureFuncs := getPureFuncs(funcs)
+
+	var markResolver func(fn *ssa.Function, scope *resolverScope)
+
+	markResolver = func(fn *ssa.Function, scope *resolverScope) {
+		scope.loops = getLoopBlocks(fn)
+		resolverFuncs[fn] = scope
+
+		for anon, scope := range getClosureScopes(fn, scope, config.RestrictedPackages) {
+			markResolver(anon, scope)
+		}
+	}
 
-	return lo.SomeBy(comments, func(item string) bool {
-		if !strings.Contains(item, "nolint") {
-			return false
+	lo.ForEach(funcs, func(fn *ssa.Function, _ int) {
+		if resolverFuncs[fn] != nil {
+			return
 		}
 
-		rawComment := strings.TrimSpace(strings.Replace(item, "//", "", 1))
-		parsedComment := lo.Map(strings.Split(rawComment, ":"), func(value string, _ int) string {
-			return strings.TrimSpace(value)
-		})
+		if pureFuncs[fn] {
+			markResolver(fn, &resolverScope{kind: kindField, root: fn, pure: true})
 
-		if len(parsedComment) == 0 {
-			return false
+			return
 		}
 
-		targets := lo.Map(strings.Split(parsedComment[1], ","), func(value string, _ int) string {
-			return strings.TrimSpace(value)
-		})
+		// Directives and field middlewares run for each field they wrap, like field resolvers.
+		if middlewares[fn] {
+			markResolver(fn, &resolverScope{kind: kindField, root: fn})
 
-		return lo.Some(targets, []string{name})
+			return
+		}
+
+		if lit, ok := fn.Syntax().(*ast.FuncLit); ok {
+			if field, ok := graphqlGoFields[lit]; ok {
+				markResolver(fn, &resolverScope{kind: field.kind(), root: fn, name: field.String()})
+
+				return
+			}
+		}
+
+		kind, isRoot, ok := getResolverKindOf(fn, resolverType, gophers, config, annotations)
+		if !ok && boundaries.Matches(fn) {
+			kind, isRoot, ok = kindBoundary, true, true
+		}
+
+		if !ok {
+			return
+		}
+
+		markResolver(fn, &resolverScope{kind: kind, root: fn, isRoot: isRoot})
 	})
+
+	return resolverFuncs
 }
 
-func getCommentFromCall(
-	pass *analysis.Pass,
-	astFile *ast.File,
-	nodePos token.Position,
-) []string {
-	return lo.FlatMap(astFile.Comments, func(comment *ast.CommentGroup, _ int) []string {
-		commentPos := pass.Fset.Position(comment.Pos())
-
-		if commentPos.Line == nodePos.Line {
-			return lo.Map(comment.List, func(item *ast.Comment, _ int) string {
-				return item.Text
-			})
-		}
+// getResolverKindOf reports whether fn is a resolver, of which kind, and whether it is a root:
+// a resolver opted out with ignoreResolverStructs or forceloader:root, which is only checked for loops.
+// gophers is nil unless resolvers are found the graph-gophers/graphql-go way.
+func getResolverKindOf(
+	fn *ssa.Function,
+	resolverType *types.TypeName,
+	gophers *gophersResolvers,
+	config *Config,
+	annotations *resolverAnnotations,
+) (kind resolverKind, isRoot bool, ok bool) {
+	isAnnotatedResolver, isAnnotatedRoot := annotations.getFuncAnnotation(fn)
+	if !isAnnotatedRoot && (isAnnotatedResolver || annotations.hasResolverType(fn)) {
+		return kindField, false, true
+	}
+
+	// Model methods gqlgen binds to fields are field resolvers, unless their model is ignored.
+	if config.bindings.IsResolver(fn) && !isAnnotatedRoot {
+		named, _ := derefNamed(fn.Signature.Recv().Type())
+
+		return kindField, false, !annotations.ignoredTypes[named.Origin().Obj()]
+	}
 
-		if commentPos.Line != nodePos.Line-1 {
-			return nil
+	if gophers != nil {
+		kind, ok := gophers.GetKind(fn)
+		if !ok {
+			return kindQuery, isAnnotatedRoot, isAnnotatedRoot
 		}
 
-		isCurrentComment := true
+		named, _ := derefNamed(fn.Signature.Recv().Type())
+		isIgnored := lo.Contains(config.IgnoreResolverStructs, qualifiedName(named.Obj())) || annotations.rootTypes[named.Origin().Obj()]
 
-		ast.Inspect(astFile, func(n ast.Node) bool {
-			if n == nil {
-				return true
-			}
+		return kind, isAnnotatedRoot || isIgnored, !annotations.ignoredTypes[named.Origin().Obj()]
+	}
 
-			nPos := pass.Fset.Position(n.Pos())
+	// Receivers and parameters are T or *T, captured variables are *T or **T.
+	values := make([]types.Type, 0, len(fn.FreeVars)+len(fn.Params))
 
-			if nPos.Line == commentPos.Line {
-				switch n.(type) {
-				case *ast.Comment, *ast.CommentGroup:
-				default:
-					isCurrentComment = false
+	lo.ForEach(fn.FreeVars, func(param *ssa.FreeVar, _ int) {
+		values = append(values, param.Type())
+	})
 
-					return false
-				}
-			}
+	lo.ForEach(fn.Params, func(param *ssa.Parameter, _ int) {
+		values = append(values, param.Type())
+	})
 
-			return true
-		})
+	kind, isRoot = kindQuery, isAnnotatedRoot
 
-		if isCurrentComment {
-			return lo.Map(comment.List, func(item *ast.Comment, _ int) string {
-				return item.Text
-			})
+	for _, t := range values {
+		named, ok := derefNamed(t)
+		if !ok || annotations.ignoredTypes[named.Origin().Obj()] {
+			continue
 		}
 
-		return nil
+		isRootType := lo.Contains(config.IgnoreResolverStructs, qualifiedName(named.Obj())) || annotations.rootTypes[named.Origin().Obj()]
+
+		switch {
+		case isRootType:
+			kind, isRoot = getResolverKind(named, config.kinds, kindQuery), true
+		case resolverType != nil && embedsType(named, resolverType):
+			return getResolverKind(named, config.kinds, kindField), isAnnotatedRoot, true
+		}
+	}
+
+	return kind, isRoot, isRoot
+}
+
+// getNolint returns the position of the nolint or forceloader:ignore directive
+// suppressing the code at pos.
+func getNolint(
+	file *fileIndex,
+	pos token.Pos,
+) (token.Pos, bool) {
+	comment, ok := lo.Find(file.Comments(pos), func(item *ast.Comment) bool {
+		if isActiveIgnoreDirective(item.Text) {
+			return true
+		}
+
+		directive, ok := parseNolintDirective(item.Text)
+
+		return ok && directive.Suppresses()
 	})
+	if !ok {
+		return token.NoPos, false
+	}
+
+	return comment.Pos(), true
 }
@@ -0,0 +1,86 @@
+package forceloader_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flum1025/forceloader"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// writeSyntheticPackage writes a module with a resolver package of n resolvers,
+// each making restricted calls with and without nolint directives.
+func writeSyntheticPackage(b *testing.B, n int) string {
+	b.Helper()
+
+	dir := b.TempDir()
+
+	files := map[string]string{
+		"go.mod":             "module bench\n\ngo 1.20\n",
+		"usecase/usecase.go": "package usecase\n\ntype UseCase interface {\n\tFuga() error\n}\n",
+	}
+
+	var src strings.Builder
+
+	src.WriteString("package graph\n\nimport (\n\t\"bench/usecase\"\n\t\"context\"\n)\n\n")
+	src.WriteString("type Resolver struct {\n\tUseCase usecase.UseCase\n}\n\n")
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&src, "type resolver%d struct{ *Resolver }\n\n", i)
+		fmt.Fprintf(&src, "// Field is the resolver for the field field.\nfunc (r *resolver%d) Field(ctx context.Context) error {\n", i)
+
+		for j := 0; j < 10; j++ {
+			src.WriteString("\t// nolint:forceloader\n\tr.UseCase.Fuga()\n")
+			src.WriteString("\tif err := r.UseCase.Fuga(); err != nil {\n\t\treturn err\n\t}\n")
+		}
+
+		src.WriteString("\treturn nil\n}\n\n")
+	}
+
+	files["graph/schema.resolvers.go"] = src.String()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func BenchmarkAnalyzer(b *testing.B) {
+	forceloader.SetResolverStruct("bench/graph.Resolver")
+	forceloader.SetRestrictedPackages("bench/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	dir := writeSyntheticPackage(b, 500)
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, "./graph")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		graph, err := checker.Analyze([]*analysis.Analyzer{forceloader.Analyzer}, pkgs, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if err := graph.Roots[0].Err; err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,5 +1,13 @@
 package forceloader
 
+import (
+	"io"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
 func SetIgnoreResolverStructs(val string) {
 	ignoreResolverStructs = &val
 }
@@ -11,3 +19,63 @@ func SetResolverStruct(val string) {
 func SetRestrictedPackages(val string) {
 	restrictedPackages = &val
 }
+
+func SetBaseline(val string) {
+	baseline = &val
+}
+
+func SetRequireNolintReason(val bool) {
+	requireNolintReason = &val
+}
+
+func SetNow(val func() time.Time) {
+	now = val
+}
+
+func SetReportEscapes(val bool) {
+	reportEscapes = &val
+}
+
+func SetConfig(val string) {
+	configFile = &val
+}
+
+func SetEnable(val string) {
+	enable = &val
+}
+
+func SetDisable(val string) {
+	disable = &val
+}
+
+func SetSeverity(val string) {
+	severity = &val
+}
+
+func SetWarnings(val string) {
+	warnings = &val
+}
+
+func SetStderr(val io.Writer) {
+	stderr = val
+}
+
+func GetConfig() (*Config, error) {
+	return getConfig()
+}
+
+// CountSSABuilds counts the packages SSA is built for until the end of the test.
+func CountSSABuilds(t *testing.T) *int {
+	count := new(int)
+	build := buildSSA
+
+	buildSSA = func(pass *analysis.Pass) (any, error) {
+		*count++
+
+		return build(pass)
+	}
+
+	t.Cleanup(func() { buildSSA = build })
+
+	return count
+}
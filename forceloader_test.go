@@ -1,10 +1,17 @@
 package forceloader_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/flum1025/forceloader"
 	"github.com/gostaticanalysis/testutil"
+	"github.com/samber/lo"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -16,3 +23,429 @@ func TestAnalyzer(t *testing.T) {
 	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
 	analysistest.Run(t, testdata, forceloader.Analyzer, "a")
 }
+
+func TestAnalyzerWithoutResolvers(t *testing.T) {
+	forceloader.SetResolverStruct("a.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("a.queryResolver,a.mutationResolver,a/rootonly.queryResolver")
+
+	builds := forceloader.CountSSABuilds(t)
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/usecase", "a/loader")
+
+	if *builds != 0 {
+		t.Errorf("SSA built for %d packages without resolvers, want 0", *builds)
+	}
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a")
+
+	if *builds == 0 {
+		t.Error("SSA not built for a package with resolvers")
+	}
+
+	// A root listed in ignoreResolverStructs is a resolver even without embedding the resolver struct.
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/rootonly")
+}
+
+func TestAnalyzerWithBaseline(t *testing.T) {
+	forceloader.SetResolverStruct("a/baseline.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetBaseline(filepath.Join(testdata, "src", "a", "baseline", "forceloader.baseline.json"))
+	t.Cleanup(func() { forceloader.SetBaseline("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/baseline")
+}
+
+func TestRatchet(t *testing.T) {
+	finding := func(resolver, snippet string) forceloader.Finding {
+		return forceloader.Finding{
+			Package:  "a",
+			Resolver: resolver,
+			Symbol:   "a/usecase.UseCase.Fuga",
+			Snippet:  snippet,
+		}
+	}
+
+	b := forceloader.NewBaseline([]forceloader.Finding{
+		finding("(*a.todoResolver).Text", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).Text", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).User", "r.UseCase.Fuga()"),
+	})
+	b.Entries = append(b.Entries, forceloader.BaselineEntry{Package: "b", Resolver: "b.resolver", Count: 1})
+
+	shrunk := forceloader.NewRatchet(b, []string{"a"}, []forceloader.Finding{
+		finding("(*a.todoResolver).Text", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).User", "r.UseCase.Fuga()"),
+	})
+
+	if len(shrunk.Gained) != 0 || !shrunk.Removed {
+		t.Errorf("unexpected ratchet: gained=%v removed=%v", shrunk.Gained, shrunk.Removed)
+	}
+
+	if got := lo.SumBy(shrunk.Baseline.Entries, func(entry forceloader.BaselineEntry) int { return entry.Count }); got != 3 {
+		t.Errorf("shrunk baseline tolerates %d findings, want 3", got)
+	}
+
+	if len(shrunk.Packages) != 1 || shrunk.Packages[0].Baseline != 3 || shrunk.Packages[0].Current != 2 {
+		t.Errorf("unexpected package summary: %v", shrunk.Packages)
+	}
+
+	gained := forceloader.NewRatchet(b, []string{"a"}, []forceloader.Finding{
+		finding("(*a.todoResolver).User", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).User", "err:=r.UseCase.Fuga()"),
+	})
+
+	if len(gained.Gained) != 1 || gained.Gained[0].Resolver != "(*a.todoResolver).User" {
+		t.Errorf("unexpected gained resolvers: %v", gained.Gained)
+	}
+
+	// Fixing a violation of a resolver does not make room for another one in the same resolver.
+	swapped := forceloader.NewRatchet(b, []string{"a"}, []forceloader.Finding{
+		finding("(*a.todoResolver).Text", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).Text", "r.UseCase.Fuga()"),
+		finding("(*a.todoResolver).User", "err:=r.UseCase.Fuga()"),
+	})
+
+	if len(swapped.Gained) != 1 || swapped.Gained[0].Resolver != "(*a.todoResolver).User" || swapped.Gained[0].New != 1 {
+		t.Errorf("unexpected gained resolvers: %v", swapped.Gained)
+	}
+
+	if !swapped.Removed {
+		t.Errorf("removed violation of (*a.todoResolver).User is not reported")
+	}
+
+	newFindings, _ := swapped.Baseline.Filter("a", []forceloader.Finding{
+		finding("(*a.todoResolver).User", "err:=r.UseCase.Fuga()"),
+	})
+	if len(newFindings) != 1 {
+		t.Errorf("shrunk baseline tolerates the new violation: %v", swapped.Baseline.Entries)
+	}
+}
+
+func TestAnalyzerWithNolint(t *testing.T) {
+	forceloader.SetResolverStruct("a/nolint.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetRequireNolintReason(true)
+	t.Cleanup(func() { forceloader.SetRequireNolintReason(false) })
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/nolint")
+}
+
+func TestAnalyzerWithIgnore(t *testing.T) {
+	forceloader.SetResolverStruct("a/ignore.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetNow(func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local) })
+	t.Cleanup(func() { forceloader.SetNow(time.Now) })
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/ignore")
+}
+
+func TestAnalyzerWithAnnotation(t *testing.T) {
+	forceloader.SetResolverStruct("a/annotation.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/annotation")
+}
+
+func TestAnalyzerWithEmbedding(t *testing.T) {
+	forceloader.SetResolverStruct("a/embedding.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/embedding")
+}
+
+func TestAnalyzerWithClosure(t *testing.T) {
+	forceloader.SetResolverStruct("a/closure.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("a/closure.queryResolver")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/closure")
+}
+
+func TestAnalyzerWithEscapes(t *testing.T) {
+	forceloader.SetResolverStruct("a/escape.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetReportEscapes(true)
+	t.Cleanup(func() { forceloader.SetReportEscapes(false) })
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/escape")
+}
+
+func TestAnalyzerWithConfig(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "rules", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/rules")
+}
+
+func TestAnalyzerWithPolicies(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "policy", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/policy")
+}
+
+func TestAnalyzerWithLoops(t *testing.T) {
+	forceloader.SetResolverStruct("a/loop.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase,a/loop")
+	forceloader.SetIgnoreResolverStructs("a/loop.queryResolver,a/loop.mutationResolver,a/loop.iterResolver")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/loop")
+}
+
+func TestAnalyzerWithBoundaries(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "boundary", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/boundary")
+}
+
+func TestAnalyzerWithGraphGophers(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "gophers", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/gophers")
+}
+
+func TestAnalyzerWithGraphqlGo(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/graphqlgo")
+}
+
+func TestAnalyzerWithAutobind(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "autobind", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/autobind")
+}
+
+func TestAnalyzerWithEagerFields(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetWarnings("report")
+	t.Cleanup(func() { forceloader.SetWarnings("") })
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	forceloader.SetConfig(filepath.Join(testdata, "src", "a", "eager", "forceloader.yml"))
+	t.Cleanup(func() { forceloader.SetConfig("") })
+
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/eager")
+}
+
+func TestAnalyzerWithMiddlewares(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/directive")
+}
+
+func TestAnalyzerWithPureFunctions(t *testing.T) {
+	forceloader.SetResolverStruct("")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/scalar")
+}
+
+func TestAnalyzerWithSharedWrites(t *testing.T) {
+	forceloader.SetResolverStruct("a/race.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/race")
+}
+
+func TestAnalyzerWithDisable(t *testing.T) {
+	forceloader.SetResolverStruct("a/rules.Resolver")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetEnable("FL002")
+	forceloader.SetDisable("restricted-call")
+	t.Cleanup(func() {
+		forceloader.SetEnable("")
+		forceloader.SetDisable("")
+	})
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/rules")
+}
+
+func TestAnalyzerWithMisconfiguration(t *testing.T) {
+	forceloader.SetResolverStruct("a/config.Resolvr")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetWarnings("report")
+	t.Cleanup(func() { forceloader.SetWarnings("") })
+
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, forceloader.Analyzer, "a/config")
+}
+
+func TestLoadConfig(t *testing.T) {
+	forceloader.SetIgnoreResolverStructs("")
+
+	tests := []struct {
+		resolverStruct     string
+		restrictedPackages string
+		want               []string
+		wantErr            bool
+	}{
+		{resolverStruct: "a.Resolver", restrictedPackages: "a/usecase, a/repository", want: []string{"a/usecase", "a/repository"}},
+		{resolverStruct: "a.Resolver", restrictedPackages: "", want: []string{}},
+		{resolverStruct: "a.Resolver", restrictedPackages: "a/usecase,", want: []string{"a/usecase"}},
+		{resolverStruct: "Resolver", restrictedPackages: "a/usecase", wantErr: true},
+		{resolverStruct: "a/graph.", restrictedPackages: "a/usecase", wantErr: true},
+		{resolverStruct: "a.Resolver", restrictedPackages: "a/use case", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		forceloader.SetResolverStruct(tt.resolverStruct)
+		forceloader.SetRestrictedPackages(tt.restrictedPackages)
+
+		config, err := forceloader.LoadConfig()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q, %q: expected an error", tt.resolverStruct, tt.restrictedPackages)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q, %q: %v", tt.resolverStruct, tt.restrictedPackages, err)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(config.RestrictedPackages, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.restrictedPackages, config.RestrictedPackages, tt.want)
+		}
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetResolverStruct("a.Resolver")
+
+	first, err := forceloader.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := forceloader.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Error("the configuration is loaded again with the same flags")
+	}
+
+	forceloader.SetResolverStruct("a.OtherResolver")
+
+	third, err := forceloader.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if third == first || third.ResolverStruct != "a.OtherResolver" {
+		t.Errorf("the configuration is not loaded again when the flags change: got %q", third.ResolverStruct)
+	}
+}
+
+func TestAnalyzerWithSeverity(t *testing.T) {
+	forceloader.SetRestrictedPackages("a/usecase")
+	forceloader.SetIgnoreResolverStructs("")
+	forceloader.SetSeverity("restricted-call=warning")
+	t.Cleanup(func() {
+		forceloader.SetSeverity("")
+		forceloader.SetWarnings("")
+		forceloader.SetStderr(os.Stderr)
+	})
+
+	tests := []struct {
+		warnings string
+		pkg      string
+		printed  bool
+	}{
+		{warnings: "report", pkg: "a/severity"},
+		{warnings: "print", pkg: "a/severity/quiet", printed: true},
+		{warnings: "drop", pkg: "a/severity/quiet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.warnings, func(t *testing.T) {
+			var stderr bytes.Buffer
+
+			forceloader.SetResolverStruct(tt.pkg + ".Resolver")
+			forceloader.SetWarnings(tt.warnings)
+			forceloader.SetStderr(&stderr)
+
+			testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+			analysistest.Run(t, testdata, forceloader.Analyzer, tt.pkg)
+
+			printed := strings.Contains(stderr.String(), "resolver.go:19:23: warning: r.UseCase.Fuga (a/usecase.UseCase.Fuga) cannot be used in (*a/severity/quiet.todoResolver).User (FL001)")
+			if printed != tt.printed {
+				t.Errorf("printed %q", stderr.String())
+			}
+		})
+	}
+}
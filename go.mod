@@ -1,20 +1,23 @@
 module github.com/flum1025/forceloader
 
-go 1.20
+go 1.22.0
 
 require (
 	github.com/gostaticanalysis/testutil v0.4.0
 	github.com/samber/lo v1.38.1
-	golang.org/x/tools v0.7.0
+	github.com/vektah/gqlparser/v2 v2.5.30
+	golang.org/x/mod v0.23.0
+	golang.org/x/tools v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/agnivade/levenshtein v1.2.1 // indirect
 	github.com/hashicorp/go-version v1.2.1 // indirect
 	github.com/otiai10/copy v1.2.0 // indirect
 	github.com/tenntenn/modver v1.0.1 // indirect
 	github.com/tenntenn/text/transform v0.0.0-20200319021203-7eef512accb3 // indirect
 	golang.org/x/exp v0.0.0-20220722155223-a9213eeb770e // indirect
-	golang.org/x/mod v0.9.0 // indirect
-	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/text v0.3.3 // indirect
 )
@@ -0,0 +1,120 @@
+package forceloader
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/ssa"
+)
+
+// The frameworks whose resolver conventions the analyzer knows.
+const (
+	// frameworkGqlgen finds resolvers by the embedded resolverStruct.
+	frameworkGqlgen = "gqlgen"
+	// frameworkGraphGophers finds resolvers from the root resolverStruct, as graph-gophers/graphql-go does by reflection.
+	frameworkGraphGophers = "graph-gophers"
+)
+
+// gophersResolvers holds the resolver types of graph-gophers/graphql-go:
+// the root resolver, and the types returned by the methods of resolver types, recursively.
+type gophersResolvers struct {
+	root   *types.TypeName
+	types  map[*types.TypeName]bool
+	schema *graphqlSchema
+}
+
+func newGophersResolvers(
+	root *types.TypeName,
+	schema *graphqlSchema,
+	restrictedPackages []string,
+) *gophersResolvers {
+	g := &gophersResolvers{
+		root:   root,
+		types:  make(map[*types.TypeName]bool),
+		schema: schema,
+	}
+
+	var walk func(named *types.Named)
+
+	walk = func(named *types.Named) {
+		obj := named.Origin().Obj()
+		if g.types[obj] || obj.Pkg() == nil || lo.Contains(restrictedPackages, obj.Pkg().Path()) {
+			return
+		}
+
+		g.types[obj] = true
+
+		methods := types.NewMethodSet(types.NewPointer(named))
+
+		for i := 0; i < methods.Len(); i++ {
+			sig, ok := methods.At(i).Type().(*types.Signature)
+			if !ok {
+				continue
+			}
+
+			for j := 0; j < sig.Results().Len(); j++ {
+				if result, ok := getElemNamed(sig.Results().At(j).Type()); ok {
+					walk(result)
+				}
+			}
+		}
+	}
+
+	if root != nil {
+		if named, ok := root.Type().(*types.Named); ok {
+			walk(named)
+		}
+	}
+
+	return g
+}
+
+// getElemNamed strips pointers, slices, arrays and channels from t and returns the named type underneath.
+func getElemNamed(t types.Type) (*types.Named, bool) {
+	for {
+		switch u := types.Unalias(t).(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Chan:
+			t = u.Elem()
+		case *types.Named:
+			return u, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// Contains reports whether named is a resolver type.
+func (g *gophersResolvers) Contains(named *types.Named) bool {
+	return g.types[named.Origin().Obj()]
+}
+
+// GetKind reports whether fn is a resolver, that is a method of the root resolver, or an exported method
+// of another resolver type resolving a field of the schema, and of which kind.
+func (g *gophersResolvers) GetKind(fn *ssa.Function) (resolverKind, bool) {
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		return "", false
+	}
+
+	named, ok := derefNamed(recv.Type())
+	if !ok || !g.Contains(named) || !token.IsExported(fn.Name()) {
+		return "", false
+	}
+
+	if named.Origin().Obj() == g.root {
+		return g.schema.RootKind(fn.Name()), true
+	}
+
+	if g.schema != nil && !g.schema.HasField(fn.Name()) {
+		return "", false
+	}
+
+	return kindField, true
+}
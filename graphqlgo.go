@@ -0,0 +1,133 @@
+package forceloader
+
+import (
+	"go/ast"
+	"go/constant"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const graphqlGoPath = "github.com/graphql-go/graphql"
+
+// graphqlGoField is a field of a graphql-go/graphql object, resolved by the Resolve closure of its graphql.Field.
+type graphqlGoField struct {
+	// object is the name of the object declaring the field, and is empty when the field is not declared in one.
+	object string
+	field  string
+}
+
+func (f graphqlGoField) String() string {
+	if f.object == "" {
+		return f.field
+	}
+
+	return f.object + "." + f.field
+}
+
+// kind returns the kind of the resolver of the field, which is a field resolver unless the object is a root type.
+func (f graphqlGoField) kind() resolverKind {
+	switch f.object {
+	case "Query":
+		return kindQuery
+	case "Mutation":
+		return kindMutation
+	case "Subscription":
+		return kindSubscription
+	default:
+		return kindField
+	}
+}
+
+// getGraphqlGoFields returns the Resolve closures of the graphql.Field literals of the package,
+// with the object and the field they resolve, as declared by the enclosing graphql.ObjectConfig and graphql.Fields.
+func getGraphqlGoFields(
+	pass *analysis.Pass,
+) map[*ast.FuncLit]graphqlGoField {
+	fields := make(map[*ast.FuncLit]graphqlGoField)
+
+	if !importsPackage(pass.Pkg, graphqlGoPath) {
+		return fields
+	}
+
+	isGraphqlGoType := func(expr ast.Expr, name string) bool {
+		named, ok := derefNamed(pass.TypesInfo.TypeOf(expr))
+
+		return ok && named.Obj().Pkg() != nil && unvendoredPath(named.Obj().Pkg().Path()) == graphqlGoPath && named.Obj().Name() == name
+	}
+
+	stringValue := func(expr ast.Expr) string {
+		value := pass.TypesInfo.Types[expr].Value
+		if value == nil || value.Kind() != constant.String {
+			return ""
+		}
+
+		return constant.StringVal(value)
+	}
+
+	for _, file := range pass.Files {
+		stack := make([]ast.Node, 0)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			if node == nil {
+				stack = stack[:len(stack)-1]
+
+				return true
+			}
+
+			stack = append(stack, node)
+
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok || !isGraphqlGoType(lit, "Field") {
+				return true
+			}
+
+			resolve, ok := getKeyValue(lit, "Resolve").(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+
+			field := graphqlGoField{}
+
+			for i := len(stack) - 2; i > 0; i-- {
+				parent, ok := stack[i-1].(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+
+				if kv, ok := stack[i].(*ast.KeyValueExpr); ok && field.field == "" && isGraphqlGoType(parent, "Fields") {
+					field.field = stringValue(kv.Key)
+				}
+
+				if isGraphqlGoType(parent, "ObjectConfig") {
+					field.object = stringValue(getKeyValue(parent, "Name"))
+
+					break
+				}
+			}
+
+			if field.field != "" {
+				fields[resolve] = field
+			}
+
+			return true
+		})
+	}
+
+	return fields
+}
+
+// getKeyValue returns the value of the element key of the struct literal lit, if any.
+func getKeyValue(lit *ast.CompositeLit, key string) ast.Expr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == key {
+			return kv.Value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+)
+
+const ignorePrefix = "//forceloader:ignore"
+
+// now is replaced in tests.
+var now = time.Now
+
+// ignoreDirective is a parsed
+// `//forceloader:ignore [until=2006-01-02] [owner=@team] [reason="..."]` comment.
+type ignoreDirective struct {
+	Until  time.Time
+	Owner  string
+	Reason string
+}
+
+func (d ignoreDirective) IsExpired() bool {
+	return !d.Until.IsZero() && !now().Before(d.Until.AddDate(0, 0, 1))
+}
+
+func isIgnoreDirective(text string) bool {
+	return text == ignorePrefix || strings.HasPrefix(text, ignorePrefix+" ")
+}
+
+func parseIgnoreDirective(text string) (ignoreDirective, error) {
+	var directive ignoreDirective
+
+	args, err := splitDirectiveArgs(strings.TrimPrefix(text, ignorePrefix))
+	if err != nil {
+		return directive, err
+	}
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return directive, fmt.Errorf("argument %q is not key=value", arg)
+		}
+
+		switch key {
+		case "until":
+			until, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+			if err != nil {
+				return directive, fmt.Errorf("until must be a date like 2006-01-02: %q", value)
+			}
+
+			directive.Until = until
+		case "owner":
+			directive.Owner = value
+		case "reason":
+			directive.Reason = value
+		default:
+			return directive, fmt.Errorf("unknown argument %q", key)
+		}
+	}
+
+	return directive, nil
+}
+
+// splitDirectiveArgs splits space separated key=value arguments,
+// where a value may be a double quoted string.
+func splitDirectiveArgs(text string) ([]string, error) {
+	args := make([]string, 0)
+	rest := strings.TrimSpace(text)
+
+	for rest != "" {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+
+		if eq := strings.Index(rest, "="); eq >= 0 && eq < end && strings.HasPrefix(rest[eq+1:], `"`) {
+			quoted, err := strconv.QuotedPrefix(rest[eq+1:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated quoted value in %q", rest)
+			}
+
+			value, err := strconv.Unquote(quoted)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value %s", quoted)
+			}
+
+			args = append(args, rest[:eq+1]+value)
+			rest = strings.TrimSpace(rest[eq+1+len(quoted):])
+
+			continue
+		}
+
+		args = append(args, rest[:end])
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	return args, nil
+}
+
+// isActiveIgnoreDirective reports whether text is a directive that suppresses violations:
+// malformed and expired directives are reported instead, and suppress nothing.
+func isActiveIgnoreDirective(text string) bool {
+	if !isIgnoreDirective(text) {
+		return false
+	}
+
+	directive, err := parseIgnoreDirective(text)
+
+	return err == nil && !directive.IsExpired()
+}
+
+// hasIgnoreDirective reports whether group holds an active directive.
+func hasIgnoreDirective(group *ast.CommentGroup) bool {
+	if group == nil {
+		return false
+	}
+
+	return lo.SomeBy(group.List, func(comment *ast.Comment) bool {
+		return isActiveIgnoreDirective(comment.Text)
+	})
+}
+
+// ignoreScope is a source range suppressed by a function- or file-scoped directive.
+type ignoreScope struct {
+	Pos token.Pos
+	End token.Pos
+}
+
+func (s ignoreScope) Contains(pos token.Pos) bool {
+	return s.Pos <= pos && pos <= s.End
+}
+
+// getIgnoreScopes returns the files with a directive before the package clause
+// and the functions with a directive in their doc comment.
+func getIgnoreScopes(
+	pass *analysis.Pass,
+) []ignoreScope {
+	return lo.FlatMap(pass.Files, func(file *ast.File, _ int) []ignoreScope {
+		isIgnoredFile := lo.SomeBy(file.Comments, func(group *ast.CommentGroup) bool {
+			return group.Pos() < file.Package && hasIgnoreDirective(group)
+		})
+		if isIgnoredFile {
+			return []ignoreScope{{Pos: file.FileStart, End: file.FileEnd}}
+		}
+
+		return lo.FilterMap(file.Decls, func(decl ast.Decl, _ int) (ignoreScope, bool) {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || !hasIgnoreDirective(funcDecl.Doc) {
+				return ignoreScope{}, false
+			}
+
+			return ignoreScope{Pos: funcDecl.Pos(), End: funcDecl.End()}, true
+		})
+	})
+}
+
+// getTypeDirectiveGroups returns the comments that can hold a directive for each type declared in file.
+func getTypeDirectiveGroups(
+	file *ast.File,
+) map[*ast.TypeSpec][]*ast.CommentGroup {
+	groups := make(map[*ast.TypeSpec][]*ast.CommentGroup)
+
+	lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			return
+		}
+
+		lo.ForEach(genDecl.Specs, func(spec ast.Spec, _ int) {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				return
+			}
+
+			groups[typeSpec] = lo.Compact([]*ast.CommentGroup{genDecl.Doc, typeSpec.Doc, typeSpec.Comment})
+		})
+	})
+
+	return groups
+}
+
+// getIgnoredResolvers returns the types declared with a directive,
+// e.g. `//forceloader:ignore` above `type viewerResolver struct{ *Resolver }`.
+func getIgnoredResolvers(
+	pass *analysis.Pass,
+) map[types.Object]bool {
+	ignored := make(map[types.Object]bool)
+
+	lo.ForEach(pass.Files, func(file *ast.File, _ int) {
+		for typeSpec, groups := range getTypeDirectiveGroups(file) {
+			if !lo.SomeBy(groups, hasIgnoreDirective) {
+				continue
+			}
+
+			if obj := pass.TypesInfo.Defs[typeSpec.Name]; obj != nil {
+				ignored[obj] = true
+			}
+		}
+	})
+
+	return ignored
+}
+
+// Suppression is a nolint:forceloader or forceloader:ignore directive.
+type Suppression struct {
+	Package  string
+	Position token.Position
+	// Directive is either "nolint" or "ignore".
+	Directive string
+	// Scope is one of "line", "function", "type" or "file".
+	Scope  string
+	Owner  string
+	Until  time.Time
+	Reason string
+
+	pos     token.Pos
+	err     error
+	expired bool
+}
+
+// getSuppressions returns the suppressions in the files of pass.
+func getSuppressions(
+	pass *analysis.Pass,
+) []Suppression {
+	return lo.FlatMap(pass.Files, func(file *ast.File, _ int) []Suppression {
+		scopes := make(map[*ast.CommentGroup]string)
+
+		lo.ForEach(file.Comments, func(group *ast.CommentGroup, _ int) {
+			if group.Pos() < file.Package {
+				scopes[group] = "file"
+			}
+		})
+
+		lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Doc != nil {
+				scopes[funcDecl.Doc] = "function"
+			}
+		})
+
+		for _, groups := range getTypeDirectiveGroups(file) {
+			lo.ForEach(groups, func(group *ast.CommentGroup, _ int) {
+				scopes[group] = "type"
+			})
+		}
+
+		return lo.FlatMap(file.Comments, func(group *ast.CommentGroup, _ int) []Suppression {
+			return lo.FilterMap(group.List, func(comment *ast.Comment, _ int) (Suppression, bool) {
+				suppression := Suppression{
+					Package:  pass.Pkg.Path(),
+					Position: pass.Fset.Position(comment.Pos()),
+					pos:      comment.Pos(),
+				}
+
+				if isIgnoreDirective(comment.Text) {
+					directive, err := parseIgnoreDirective(comment.Text)
+
+					suppression.Directive = "ignore"
+					suppression.Scope = lo.ValueOr(scopes, group, "line")
+					suppression.Owner = directive.Owner
+					suppression.Until = directive.Until
+					suppression.Reason = directive.Reason
+					suppression.err = err
+					suppression.expired = directive.IsExpired()
+
+					return suppression, true
+				}
+
+				directive, ok := parseNolintDirective(comment.Text)
+				if !ok || !directive.Names() {
+					return Suppression{}, false
+				}
+
+				suppression.Directive = "nolint"
+				suppression.Scope = "line"
+				suppression.Reason = directive.Reason
+
+				return suppression, true
+			})
+		})
+	})
+}
+
+// reportIgnoreDirectives reports malformed and expired forceloader:ignore directives.
+func reportIgnoreDirectives(
+	pass *analysis.Pass,
+	config *Config,
+	suppressions []Suppression,
+) {
+	lo.ForEach(suppressions, func(suppression Suppression, _ int) {
+		if suppression.err != nil {
+			config.report(pass, InvalidSuppression, analysis.Diagnostic{
+				Pos:     suppression.pos,
+				Message: fmt.Sprintf("invalid forceloader:ignore directive: %s", suppression.err),
+			})
+
+			return
+		}
+
+		if !suppression.expired {
+			return
+		}
+
+		message := fmt.Sprintf("forceloader:ignore directive expired on %s", suppression.Until.Format(time.DateOnly))
+		if suppression.Owner != "" {
+			message += fmt.Sprintf(" (owner %s)", suppression.Owner)
+		}
+
+		config.report(pass, ExpiredSuppression, analysis.Diagnostic{
+			Pos:     suppression.pos,
+			Message: message,
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package forceloader
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// passIndex indexes the files of a pass lazily, so that each file is walked at most once.
+type passIndex struct {
+	pass  *analysis.Pass
+	asts  map[*token.File]*ast.File
+	files map[*token.File]*fileIndex
+}
+
+// fileIndex holds the comments and the source lines of a file by line number.
+type fileIndex struct {
+	pass      *analysis.Pass
+	file      *ast.File
+	tokenFile *token.File
+	// groups holds the comment groups by the line they start on.
+	groups map[int][]*ast.CommentGroup
+	// codeLines holds the lines on which a node other than a comment starts.
+	codeLines map[int]bool
+	lines     []string
+}
+
+func newPassIndex(
+	pass *analysis.Pass,
+) *passIndex {
+	asts := make(map[*token.File]*ast.File, len(pass.Files))
+
+	for _, file := range pass.Files {
+		asts[pass.Fset.File(file.Package)] = file
+	}
+
+	return &passIndex{
+		pass:  pass,
+		asts:  asts,
+		files: make(map[*token.File]*fileIndex),
+	}
+}
+
+// File returns the index of the file containing pos, or nil if pos is not in the files of the pass.
+func (p *passIndex) File(pos token.Pos) *fileIndex {
+	tokenFile := p.pass.Fset.File(pos)
+	if tokenFile == nil {
+		return nil
+	}
+
+	if index, ok := p.files[tokenFile]; ok {
+		return index
+	}
+
+	file, ok := p.asts[tokenFile]
+	if !ok {
+		return nil
+	}
+
+	index := &fileIndex{
+		pass:      p.pass,
+		file:      file,
+		tokenFile: tokenFile,
+		groups:    make(map[int][]*ast.CommentGroup),
+		codeLines: make(map[int]bool),
+	}
+
+	for _, group := range file.Comments {
+		line := tokenFile.Line(group.Pos())
+		index.groups[line] = append(index.groups[line], group)
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.Comment, *ast.CommentGroup:
+		default:
+			index.codeLines[tokenFile.Line(n.Pos())] = true
+		}
+
+		return true
+	})
+
+	p.files[tokenFile] = index
+
+	return index
+}
+
+// Comments returns the comments on the line of pos,
+// and those on the line above when it holds nothing but comments.
+func (f *fileIndex) Comments(pos token.Pos) []*ast.Comment {
+	line := f.tokenFile.Line(pos)
+	comments := make([]*ast.Comment, 0)
+
+	for _, group := range f.groups[line] {
+		comments = append(comments, group.List...)
+	}
+
+	if !f.codeLines[line-1] {
+		for _, group := range f.groups[line-1] {
+			comments = append(comments, group.List...)
+		}
+	}
+
+	return comments
+}
+
+// Line returns the source of the line of pos.
+func (f *fileIndex) Line(pos token.Pos) string {
+	if f.lines == nil {
+		// A file that cannot be read has no lines to render.
+		src, _ := f.pass.ReadFile(f.tokenFile.Name())
+		f.lines = strings.Split(string(src), "\n")
+	}
+
+	line := f.tokenFile.Line(pos)
+	if line < 1 || len(f.lines) < line {
+		return ""
+	}
+
+	return f.lines[line-1]
+}
@@ -0,0 +1,146 @@
+package forceloader
+
+import (
+	"go/types"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	gqlgenHandlerPath = "github.com/99designs/gqlgen/graphql/handler"
+	// directiveRootName is the name gqlgen gives the struct of the directive implementations in the generated Config.
+	directiveRootName = "DirectiveRoot"
+)
+
+// gqlgenMiddlewares are the methods of handler.Server registering middlewares run for each field.
+var gqlgenMiddlewares = []string{"AroundFields", "AroundResponses"}
+
+// isGeneratedRoot reports whether t is, or points to, the struct gqlgen generates with the name.
+func isGeneratedRoot(t types.Type, name string) bool {
+	named, ok := derefNamed(t)
+
+	return ok && named.Obj().Name() == name
+}
+
+// isMiddlewareRegistration reports whether obj is a method of handler.Server registering a field middleware.
+func isMiddlewareRegistration(obj types.Object) bool {
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+
+	return unvendoredPath(fn.Pkg().Path()) == gqlgenHandlerPath && lo.Contains(gqlgenMiddlewares, fn.Name())
+}
+
+// mayContainMiddlewares reports whether the package uses a DirectiveRoot or calls AroundFields or AroundResponses.
+func mayContainMiddlewares(
+	pass *analysis.Pass,
+) bool {
+	for _, obj := range pass.TypesInfo.Uses {
+		if isMiddlewareRegistration(obj) {
+			return true
+		}
+
+		if v, ok := obj.(*types.Var); ok && isGeneratedRoot(v.Type(), directiveRootName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMiddlewareFuncs returns the functions of the package run for each field, like field resolvers:
+// the directive implementations stored into the fields of a DirectiveRoot,
+// and the middlewares given to handler.Server.AroundFields and AroundResponses.
+func getMiddlewareFuncs(
+	funcs []*ssa.Function,
+) map[*ssa.Function]bool {
+	middlewares := make(map[*ssa.Function]bool)
+
+	add := func(fn *ssa.Function, v ssa.Value) {
+		lo.ForEach(getFuncValues(fn, v), func(impl *ssa.Function, _ int) {
+			middlewares[impl] = true
+		})
+	}
+
+	lo.ForEach(funcs, func(fn *ssa.Function, _ int) {
+		lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+			lo.ForEach(block.Instrs, func(inst ssa.Instruction, _ int) {
+				switch inst := inst.(type) {
+				case *ssa.Store:
+					addr, ok := inst.Addr.(*ssa.FieldAddr)
+					if ok && isGeneratedRoot(addr.X.Type(), directiveRootName) {
+						add(fn, inst.Val)
+					}
+				case *ssa.Call:
+					callee := inst.Call.StaticCallee()
+					if callee == nil || callee.Object() == nil || !isMiddlewareRegistration(callee.Object()) {
+						return
+					}
+
+					lo.ForEach(inst.Call.Args, func(arg ssa.Value, _ int) {
+						add(fn, arg)
+					})
+				}
+			})
+		})
+	})
+
+	return middlewares
+}
+
+// getFuncValues returns the functions of the package of fn that v, used by fn, holds.
+// A function of the package may build them, like hasRole(uc).
+func getFuncValues(fn *ssa.Function, v ssa.Value) []*ssa.Function {
+	funcs := []*ssa.Function{getFuncValue(v)}
+
+	if call, ok := v.(*ssa.Call); ok {
+		funcs = getReturnedFuncs(call.Call.StaticCallee())
+	}
+
+	return lo.Filter(funcs, func(impl *ssa.Function, _ int) bool {
+		return impl != nil && impl.Pkg == fn.Pkg
+	})
+}
+
+// getReturnedFuncs returns the functions fn returns.
+func getReturnedFuncs(fn *ssa.Function) []*ssa.Function {
+	if fn == nil {
+		return nil
+	}
+
+	funcs := make([]*ssa.Function, 0)
+
+	lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+		if ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok && len(ret.Results) == 1 {
+			funcs = append(funcs, getFuncValue(ret.Results[0]))
+		}
+	})
+
+	return funcs
+}
+
+// getFuncValue returns the function v holds, looking through closures and method values, if known.
+func getFuncValue(v ssa.Value) *ssa.Function {
+	if conv, ok := v.(*ssa.ChangeType); ok {
+		v = conv.X
+	}
+
+	if mc, ok := v.(*ssa.MakeClosure); ok {
+		v = mc.Fn
+	}
+
+	fn, ok := v.(*ssa.Function)
+	if !ok {
+		return nil
+	}
+
+	// A method value r.hasRole is a closure over a wrapper of the method.
+	if method, ok := fn.Object().(*types.Func); ok && fn.Synthetic != "" {
+		return fn.Prog.FuncValue(method)
+	}
+
+	return fn
+}
@@ -0,0 +1,89 @@
+package forceloader
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+)
+
+// nolintDirective is a parsed `//nolint[:linter,...] [// reason]` comment.
+type nolintDirective struct {
+	Pos token.Pos
+	// Linters is empty when the directive applies to every linter.
+	Linters []string
+	Reason  string
+}
+
+// parseNolintDirective parses text of a line comment.
+// It reports false if the comment is not a well-formed nolint directive.
+func parseNolintDirective(text string) (nolintDirective, bool) {
+	if !strings.HasPrefix(text, "//") {
+		return nolintDirective{}, false
+	}
+
+	body := strings.TrimSpace(strings.TrimPrefix(text, "//"))
+
+	rest, ok := strings.CutPrefix(body, "nolint")
+	if !ok {
+		return nolintDirective{}, false
+	}
+
+	rest, reason, _ := strings.Cut(rest, "//")
+	reason = strings.TrimSpace(reason)
+	rest = strings.TrimSpace(rest)
+
+	if rest == "" {
+		return nolintDirective{Reason: reason}, true
+	}
+
+	list, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return nolintDirective{}, false
+	}
+
+	linters := lo.Map(strings.Split(list, ","), func(value string, _ int) string {
+		return strings.TrimSpace(value)
+	})
+
+	isValid := lo.EveryBy(linters, func(linter string) bool {
+		return linter != "" && !strings.ContainsAny(linter, " \t:")
+	})
+	if !isValid {
+		return nolintDirective{}, false
+	}
+
+	return nolintDirective{Linters: linters, Reason: reason}, true
+}
+
+// Suppresses reports whether the directive suppresses forceloader.
+func (d nolintDirective) Suppresses() bool {
+	return len(d.Linters) == 0 || lo.Contains(d.Linters, name)
+}
+
+// Names reports whether the directive names forceloader explicitly.
+func (d nolintDirective) Names() bool {
+	return lo.Contains(d.Linters, name)
+}
+
+// getNolintDirectives returns the directives naming forceloader in the files of pass.
+func getNolintDirectives(
+	pass *analysis.Pass,
+) []nolintDirective {
+	return lo.FlatMap(pass.Files, func(file *ast.File, _ int) []nolintDirective {
+		return lo.FlatMap(file.Comments, func(group *ast.CommentGroup, _ int) []nolintDirective {
+			return lo.FilterMap(group.List, func(comment *ast.Comment, _ int) (nolintDirective, bool) {
+				directive, ok := parseNolintDirective(comment.Text)
+				if !ok || !directive.Names() {
+					return nolintDirective{}, false
+				}
+
+				directive.Pos = comment.Pos()
+
+				return directive, true
+			})
+		})
+	})
+}
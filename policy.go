@@ -0,0 +1,366 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/ssa"
+)
+
+// resolverKind tells what a resolver resolves, and selects the policy applied to it.
+type resolverKind string
+
+const (
+	kindField        resolverKind = "field"
+	kindQuery        resolverKind = "query"
+	kindMutation     resolverKind = "mutation"
+	kindSubscription resolverKind = "subscription"
+	kindEntity       resolverKind = "entity"
+	// kindBoundary is the kind of the boundaries outside of GraphQL, such as HTTP handlers.
+	kindBoundary resolverKind = "boundary"
+)
+
+// gqlgenKinds are the kinds of the root resolvers by the names gqlgen gives their types.
+var gqlgenKinds = map[string]resolverKind{
+	"queryResolver":        kindQuery,
+	"mutationResolver":     kindMutation,
+	"subscriptionResolver": kindSubscription,
+	"entityResolver":       kindEntity,
+}
+
+func parseResolverKind(s string) (resolverKind, error) {
+	kind := resolverKind(strings.TrimSpace(s))
+	if _, ok := defaultPolicies[kind]; !ok {
+		return "", fmt.Errorf("unknown resolver kind %q, must be one of field, query, mutation, subscription, entity or boundary", s)
+	}
+
+	return kind, nil
+}
+
+// Policy tells which restricted calls a kind of resolver may make.
+// Unset fields keep the default of the kind.
+type Policy struct {
+	// Calls is how many restricted calls a resolver may make on any path, or -1 for any number.
+	// Calls in loops and goroutines are governed by Loops and Goroutines instead.
+	Calls *int `yaml:"calls"`
+	// Loops tells whether restricted calls are allowed inside loops.
+	Loops *bool `yaml:"loops"`
+	// Goroutines tells whether restricted calls are allowed in goroutines the resolver starts.
+	Goroutines *bool `yaml:"goroutines"`
+}
+
+// policy is a Policy with the defaults of its kind applied.
+type policy struct {
+	calls      int
+	loops      bool
+	goroutines bool
+}
+
+var defaultPolicies = map[resolverKind]policy{
+	kindField: {calls: 0},
+	// A root query runs once per request, but must not fetch per item.
+	kindQuery: {calls: 1},
+	// Mutations run once per request, one after another, and may use the usecases directly.
+	kindMutation: {calls: -1, loops: true, goroutines: true},
+	// The body of a subscription runs once, but its goroutine runs once per event.
+	kindSubscription: {calls: -1, loops: true},
+	kindEntity:       {calls: 0},
+	// A boundary handles a whole request, and must only not fetch per item.
+	kindBoundary: {calls: -1, goroutines: true},
+}
+
+func (p Policy) apply(base policy) policy {
+	return policy{
+		calls:      lo.FromPtrOr(p.Calls, base.calls),
+		loops:      lo.FromPtrOr(p.Loops, base.loops),
+		goroutines: lo.FromPtrOr(p.Goroutines, base.goroutines),
+	}
+}
+
+// resolverScope tells where a function runs within the resolver declaring it.
+type resolverScope struct {
+	kind resolverKind
+	// root is the resolver the function is, or is declared in.
+	root *ssa.Function
+	// name names the resolver in diagnostics instead of its function, like "Todo.user" for graphql-go/graphql.
+	name string
+	// isRoot tells that the resolver is opted out of the checks other than the loop one.
+	isRoot bool
+	// inLoop tells that the function is declared inside a loop of the resolver.
+	inLoop bool
+	// inGoroutine tells that the function runs in a goroutine started by the resolver.
+	inGoroutine bool
+	// pure tells that the function must make no restricted call, even through helpers, like a scalar marshaler.
+	pure bool
+	// loops holds the blocks of the function that belong to a loop.
+	loops map[*ssa.BasicBlock]bool
+}
+
+// getResolverKind returns the kind of the resolver named, from the configuration or from the gqlgen naming.
+func getResolverKind(
+	named *types.Named,
+	kinds map[string]resolverKind,
+	fallback resolverKind,
+) resolverKind {
+	if kind, ok := kinds[qualifiedName(named.Obj())]; ok {
+		return kind
+	}
+
+	if kind, ok := gqlgenKinds[named.Obj().Name()]; ok {
+		return kind
+	}
+
+	return fallback
+}
+
+// getLoopBlocks returns the blocks of fn that belong to a loop, that is the natural loops
+// of the back edges: the edges to a block that dominates their source.
+func getLoopBlocks(fn *ssa.Function) map[*ssa.BasicBlock]bool {
+	loops := make(map[*ssa.BasicBlock]bool)
+
+	lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+		lo.ForEach(block.Succs, func(header *ssa.BasicBlock, _ int) {
+			if !header.Dominates(block) {
+				return
+			}
+
+			// The body is the header and every block reaching the back edge without going through the header.
+			body := map[*ssa.BasicBlock]bool{header: true}
+			stack := []*ssa.BasicBlock{block}
+
+			for len(stack) > 0 {
+				b := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+
+				if body[b] {
+					continue
+				}
+
+				body[b] = true
+				stack = append(stack, b.Preds...)
+			}
+
+			for b := range body {
+				loops[b] = true
+			}
+		})
+	})
+
+	return loops
+}
+
+// getClosureScopes returns the scopes of the closures fn declares, from where they are created.
+// A closure given to a call that iterates over the result of a restricted call is a loop body too,
+// such as the yield function of a range over an iterator, or a callback of lo.ForEach.
+func getClosureScopes(
+	fn *ssa.Function,
+	scope *resolverScope,
+	restrictedPackages []string,
+) map[*ssa.Function]*resolverScope {
+	scopes := make(map[*ssa.Function]*resolverScope, len(fn.AnonFuncs))
+
+	lo.ForEach(fn.AnonFuncs, func(anon *ssa.Function, _ int) {
+		scopes[anon] = &resolverScope{
+			kind:        scope.kind,
+			root:        scope.root,
+			name:        scope.name,
+			isRoot:      scope.isRoot,
+			inLoop:      scope.inLoop,
+			inGoroutine: scope.inGoroutine,
+			pure:        scope.pure,
+		}
+	})
+
+	lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+		lo.ForEach(block.Instrs, func(inst ssa.Instruction, _ int) {
+			var started ssa.Value
+			if g, ok := inst.(*ssa.Go); ok {
+				started = g.Call.Value
+			}
+
+			if isIterationOverRestricted(inst, restrictedPackages) {
+				lo.ForEach(getCallbacks(inst), func(anon *ssa.Function, _ int) {
+					if scopes[anon] != nil {
+						scopes[anon].inLoop = true
+					}
+				})
+			}
+
+			lo.ForEach(inst.Operands(nil), func(operand *ssa.Value, _ int) {
+				if operand == nil {
+					return
+				}
+
+				anon, ok := (*operand).(*ssa.Function)
+				if !ok || scopes[anon] == nil {
+					return
+				}
+
+				scopes[anon].inLoop = scopes[anon].inLoop || scope.loops[block]
+
+				// A closure without free variables is started as is, others through their MakeClosure.
+				mc, ok := inst.(*ssa.MakeClosure)
+				if started == anon || ok && isStartedByGo(mc) {
+					scopes[anon].inGoroutine = true
+				}
+			})
+		})
+	})
+
+	return scopes
+}
+
+// iterationNames are the prefixes of the names of the functions and methods that call back for each item,
+// like lo.ForEach, lo.Map or a restricted Each method.
+var iterationNames = []string{"each", "foreach", "map", "flatmap", "filter", "reduce", "range", "iterate", "walk", "times"}
+
+// isIterationOverRestricted reports whether inst is a call of the result of a restricted call,
+// like `seq(yield)` for `range r.UseCase.All()`, or a call of an iteration function over the result of a restricted call,
+// like `lo.ForEach(r.UseCase.List(), f)`, or of a restricted iteration method, like `r.UseCase.Each(f)`.
+// Other calls taking callbacks, such as `r.UseCase.WithTx(f)`, call them once.
+func isIterationOverRestricted(
+	inst ssa.Instruction,
+	restrictedPackages []string,
+) bool {
+	call, ok := inst.(*ssa.Call)
+	if !ok {
+		return false
+	}
+
+	isRestrictedResult := func(v ssa.Value) bool {
+		if extract, ok := v.(*ssa.Extract); ok {
+			v = extract.Tuple
+		}
+
+		result, ok := v.(*ssa.Call)
+		if !ok {
+			return false
+		}
+
+		_, ok = getRestrictedTarget(result, restrictedPackages)
+
+		return ok
+	}
+
+	if isRestrictedResult(call.Call.Value) {
+		return true
+	}
+
+	if !isIterationCall(call.Common()) {
+		return false
+	}
+
+	if _, ok := getRestrictedTarget(call, restrictedPackages); ok {
+		return true
+	}
+
+	return lo.SomeBy(call.Call.Args, isRestrictedResult)
+}
+
+// isIterationCall reports whether call calls a function or a method named like one calling back for each item.
+func isIterationCall(call *ssa.CallCommon) bool {
+	var obj types.Object
+	if call.IsInvoke() {
+		obj = call.Method
+	} else if callee := call.StaticCallee(); callee != nil {
+		obj = callee.Object()
+	}
+
+	if obj == nil {
+		return false
+	}
+
+	return lo.SomeBy(iterationNames, func(name string) bool {
+		return strings.HasPrefix(strings.ToLower(obj.Name()), name)
+	})
+}
+
+// getCallbacks returns the closures given as arguments to the call inst.
+func getCallbacks(
+	inst ssa.Instruction,
+) []*ssa.Function {
+	call, ok := inst.(*ssa.Call)
+	if !ok {
+		return nil
+	}
+
+	return lo.FilterMap(call.Call.Args, func(arg ssa.Value, _ int) (*ssa.Function, bool) {
+		if mc, ok := arg.(*ssa.MakeClosure); ok {
+			arg = mc.Fn
+		}
+
+		fn, ok := arg.(*ssa.Function)
+
+		return fn, ok
+	})
+}
+
+// isStartedByGo reports whether the closure made by mc is run by a go statement.
+func isStartedByGo(mc *ssa.MakeClosure) bool {
+	refs := mc.Referrers()
+	if refs == nil {
+		return false
+	}
+
+	return lo.SomeBy(*refs, func(ref ssa.Instruction) bool {
+		g, ok := ref.(*ssa.Go)
+
+		return ok && g.Call.Value == mc
+	})
+}
+
+// pathCalls counts the restricted calls made on the path through the dominator tree to each block,
+// up to the last call counted in the block, so that the calls of exclusive branches are not summed.
+// The path of a closure goes on in the block declaring it.
+type pathCalls map[*ssa.BasicBlock]int
+
+// get returns the calls counted on the path to block.
+func (c pathCalls) get(block *ssa.BasicBlock) int {
+	for block != nil {
+		if count, ok := c[block]; ok {
+			return count
+		}
+
+		if block.Index != 0 {
+			block = block.Idom()
+
+			continue
+		}
+
+		mc, ok := getMakeClosure(block.Parent())
+		if !ok {
+			return 0
+		}
+
+		block = mc.Block()
+	}
+
+	return 0
+}
+
+// add counts a call in block, and returns the calls on the path to it.
+func (c pathCalls) add(block *ssa.BasicBlock) int {
+	c[block] = c.get(block) + 1
+
+	return c[block]
+}
+
+// getMakeClosure returns the instruction of the enclosing function that makes the closure fn, if any.
+// A closure without free variables is used as is, without a MakeClosure.
+func getMakeClosure(fn *ssa.Function) (*ssa.MakeClosure, bool) {
+	if fn.Parent() == nil {
+		return nil, false
+	}
+
+	for _, block := range fn.Parent().Blocks {
+		for _, inst := range block.Instrs {
+			if mc, ok := inst.(*ssa.MakeClosure); ok && mc.Fn == fn {
+				return mc, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,117 @@
+package forceloader
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	gqlgenGraphqlPath = "github.com/99designs/gqlgen/graphql"
+	// complexityRootName is the name gqlgen gives the struct of the complexity functions in the generated Config.
+	complexityRootName = "ComplexityRoot"
+)
+
+// scalarMethods are the methods of the custom scalars gqlgen calls for each value.
+var scalarMethods = []string{"MarshalGQL", "UnmarshalGQL", "MarshalGQLContext", "UnmarshalGQLContext"}
+
+// isMarshalerFunc reports whether sig is a function returning a graphql.Marshaler, like MarshalTimestamp.
+func isMarshalerFunc(sig *types.Signature) bool {
+	if sig.Recv() != nil {
+		return false
+	}
+
+	return lo.SomeBy(lo.Range(sig.Results().Len()), func(i int) bool {
+		named, ok := derefNamed(sig.Results().At(i).Type())
+		if !ok || named.Obj().Pkg() == nil || unvendoredPath(named.Obj().Pkg().Path()) != gqlgenGraphqlPath {
+			return false
+		}
+
+		return named.Obj().Name() == "Marshaler" || named.Obj().Name() == "ContextMarshaler"
+	})
+}
+
+// mayContainPureFuncs reports whether the package declares a method of a custom scalar,
+// a function returning a graphql.Marshaler, or uses a ComplexityRoot.
+func mayContainPureFuncs(
+	pass *analysis.Pass,
+) bool {
+	for _, obj := range pass.TypesInfo.Defs {
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if ok && (sig.Recv() != nil && lo.Contains(scalarMethods, fn.Name()) || isMarshalerFunc(sig)) {
+			return true
+		}
+	}
+
+	for _, obj := range pass.TypesInfo.Uses {
+		if v, ok := obj.(*types.Var); ok && isGeneratedRoot(v.Type(), complexityRootName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPureFuncs returns the functions of the package that gqlgen runs for each value or field, and must be pure:
+// the marshalers of custom scalars, with the functions returning a graphql.Marshaler and their unmarshalers,
+// and the complexity functions stored into the fields of a ComplexityRoot.
+func getPureFuncs(
+	funcs []*ssa.Function,
+) map[*ssa.Function]bool {
+	pure := make(map[*ssa.Function]bool)
+
+	lo.ForEach(funcs, func(fn *ssa.Function, _ int) {
+		if fn.Signature.Recv() != nil && lo.Contains(scalarMethods, fn.Name()) {
+			pure[fn] = true
+		}
+
+		if fn.Parent() == nil && isMarshalerFunc(fn.Signature) {
+			pure[fn] = true
+
+			// MarshalTimestamp goes with UnmarshalTimestamp.
+			if name, ok := strings.CutPrefix(fn.Name(), "Marshal"); ok && fn.Pkg.Func("Unmarshal"+name) != nil {
+				pure[fn.Pkg.Func("Unmarshal"+name)] = true
+			}
+		}
+
+		lo.ForEach(fn.Blocks, func(block *ssa.BasicBlock, _ int) {
+			lo.ForEach(block.Instrs, func(inst ssa.Instruction, _ int) {
+				store, ok := inst.(*ssa.Store)
+				if !ok || !isInComplexityRoot(store.Addr) {
+					return
+				}
+
+				lo.ForEach(getFuncValues(fn, store.Val), func(impl *ssa.Function, _ int) {
+					pure[impl] = true
+				})
+			})
+		})
+	})
+
+	return pure
+}
+
+// isInComplexityRoot reports whether addr is a field of a ComplexityRoot, at any depth,
+// as the complexity functions are grouped by object like c.Complexity.Query.Todos.
+func isInComplexityRoot(addr ssa.Value) bool {
+	for {
+		field, ok := addr.(*ssa.FieldAddr)
+		if !ok {
+			return false
+		}
+
+		if isGeneratedRoot(field.X.Type(), complexityRootName) {
+			return true
+		}
+
+		addr = field.X
+	}
+}
@@ -0,0 +1,229 @@
+package forceloader
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/samber/lo"
+	"golang.org/x/tools/go/ssa"
+)
+
+// objParam is the name gqlgen gives the parameter of field resolvers holding the parent object.
+const objParam = "obj"
+
+// sharedWrite is a write to state shared by the field resolvers gqlgen runs concurrently.
+type sharedWrite struct {
+	Pos token.Pos
+	// Target describes what is written, like "receiver r" or "package variable a/graph.hits".
+	Target string
+}
+
+// getSharedWrite reports whether inst of fn writes to a package variable, or to what the receiver
+// or the obj parameter of the field resolver point to, without a lock held.
+func getSharedWrite(
+	fn *ssa.Function,
+	scope *resolverScope,
+	inst ssa.Instruction,
+) (sharedWrite, bool) {
+	var addr ssa.Value
+
+	switch inst := inst.(type) {
+	case *ssa.Store:
+		addr = inst.Addr
+	case *ssa.MapUpdate:
+		addr = inst.Map
+	default:
+		return sharedWrite{}, false
+	}
+
+	target, ok := getSharedTarget(addr, scope.root)
+	if !ok || !inst.Pos().IsValid() || isLocked(inst) {
+		return sharedWrite{}, false
+	}
+
+	return sharedWrite{Pos: inst.Pos(), Target: target}, true
+}
+
+// getSharedTarget follows the fields, elements and pointers v is reached through, and the variables closures capture,
+// and reports whether it ends at a package variable, or at the receiver or the obj parameter of the resolver root.
+func getSharedTarget(v ssa.Value, root *ssa.Function) (string, bool) {
+	for {
+		switch x := v.(type) {
+		case *ssa.FieldAddr:
+			v = x.X
+		case *ssa.IndexAddr:
+			v = x.X
+		case *ssa.Field:
+			v = x.X
+		case *ssa.Index:
+			v = x.X
+		case *ssa.UnOp:
+			if x.Op != token.MUL {
+				return "", false
+			}
+
+			v = x.X
+		case *ssa.FreeVar:
+			binding, ok := getFreeVarBinding(x)
+			if !ok {
+				return "", false
+			}
+
+			v = binding
+		case *ssa.Alloc:
+			param, ok := getSpilledParam(x)
+			if !ok {
+				return "", false
+			}
+
+			v = param
+		case *ssa.Global:
+			return "package variable " + x.Object().Pkg().Path() + "." + x.Name(), true
+		case *ssa.Parameter:
+			if x.Parent() != root {
+				return "", false
+			}
+
+			if x.Parent().Signature.Recv() != nil && x == x.Parent().Params[0] {
+				return "receiver " + x.Name(), true
+			}
+
+			return "parent " + x.Name(), x.Name() == objParam
+		default:
+			return "", false
+		}
+	}
+}
+
+// getFreeVarBinding returns the value of the enclosing function that the closure captures as v.
+func getFreeVarBinding(v *ssa.FreeVar) (ssa.Value, bool) {
+	mc, ok := getMakeClosure(v.Parent())
+	if !ok {
+		return nil, false
+	}
+
+	return mc.Bindings[lo.IndexOf(v.Parent().FreeVars, v)], true
+}
+
+// getSpilledParam returns the parameter alloc holds, as parameters that closures capture live in an Alloc,
+// unless the parameter is assigned to.
+func getSpilledParam(alloc *ssa.Alloc) (*ssa.Parameter, bool) {
+	stores := lo.FilterMap(*alloc.Referrers(), func(inst ssa.Instruction, _ int) (*ssa.Store, bool) {
+		store, ok := inst.(*ssa.Store)
+
+		return store, ok && store.Addr == alloc
+	})
+	if len(stores) != 1 {
+		return nil, false
+	}
+
+	param, ok := stores[0].Val.(*ssa.Parameter)
+
+	return param, ok
+}
+
+// isLocked reports whether a sync.Mutex, a sync.RWMutex or a sync.Locker is held at inst: locked before it,
+// in its block or in a block dominating it, and not unlocked since. Locks are told apart by the path to the mutex,
+// like r.mu. A local mutex guards nothing shared, and a read lock, taken with RLock, does not guard writes.
+func isLocked(inst ssa.Instruction) bool {
+	blocks := make([]*ssa.BasicBlock, 0)
+	for b := inst.Block(); b != nil; b = b.Idom() {
+		blocks = append(blocks, b)
+	}
+
+	held := make(map[string]int)
+
+	for _, b := range lo.Reverse(blocks) {
+		for _, i := range b.Instrs {
+			if i == inst {
+				break
+			}
+
+			call, ok := i.(*ssa.Call)
+			if !ok {
+				continue
+			}
+
+			key, ok := getMutexKey(call.Common())
+			if !ok {
+				continue
+			}
+
+			switch getSyncMethod(call.Common()) {
+			case "Lock":
+				held[key]++
+			case "Unlock":
+				held[key] = lo.Max([]int{held[key] - 1, 0})
+			}
+		}
+	}
+
+	return lo.SomeBy(lo.Values(held), func(count int) bool {
+		return count > 0
+	})
+}
+
+// getSyncMethod returns the name of the method of the sync package call calls, if any.
+func getSyncMethod(call *ssa.CallCommon) string {
+	var obj types.Object
+	if call.IsInvoke() {
+		obj = call.Method
+	} else if callee := call.StaticCallee(); callee != nil {
+		obj = callee.Object()
+	}
+
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != "sync" {
+		return ""
+	}
+
+	return obj.Name()
+}
+
+// getMutexKey describes the path to the mutex call is made on, like "r.0.*.1" for r.mu through an embedded *Resolver.
+// It reports false for a local mutex, and for a mutex it cannot follow.
+func getMutexKey(call *ssa.CallCommon) (string, bool) {
+	v := call.Value
+	if !call.IsInvoke() {
+		if len(call.Args) == 0 {
+			return "", false
+		}
+
+		v = call.Args[0]
+	}
+
+	key := ""
+
+	for {
+		switch x := v.(type) {
+		case *ssa.FieldAddr:
+			key = fmt.Sprintf(".%d", x.Field) + key
+			v = x.X
+		case *ssa.Field:
+			key = fmt.Sprintf(".%d", x.Field) + key
+			v = x.X
+		case *ssa.UnOp:
+			if x.Op != token.MUL {
+				return "", false
+			}
+
+			key = ".*" + key
+			v = x.X
+		case *ssa.Global:
+			return x.Object().Pkg().Path() + "." + x.Name() + key, true
+		case *ssa.Parameter:
+			return x.Name() + key, true
+		case *ssa.Alloc:
+			param, ok := getSpilledParam(x)
+			if !ok {
+				return "", false
+			}
+
+			return param.Name() + key, true
+		case *ssa.FreeVar:
+			return x.Name() + key, true
+		default:
+			return "", false
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package forceloader
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// Ratchet compares the findings of a run with a baseline so that the number
+// of tolerated violations can only go down.
+type Ratchet struct {
+	// Gained lists resolvers that have violations the baseline does not tolerate.
+	Gained []ResolverCount
+	// Removed reports whether any violation tolerated by the baseline is gone.
+	Removed bool
+	// Packages summarises the analysed packages that have or had violations.
+	Packages []PackageSummary
+	// Baseline is the baseline shrunk to the current violations.
+	Baseline *Baseline
+}
+
+type ResolverCount struct {
+	Package  string
+	Resolver string
+	Baseline int
+	Current  int
+	// New is the number of current violations the baseline does not tolerate, compared by fingerprint,
+	// so that fixing one violation does not make room for another.
+	New int
+}
+
+type PackageSummary struct {
+	Package   string
+	Resolvers int
+	Baseline  int
+	Current   int
+}
+
+type resolverKey struct {
+	pkg      string
+	resolver string
+}
+
+// NewRatchet compares findings of the analysed packages pkgs with b.
+// Entries of packages that were not analysed are kept as they are.
+func NewRatchet(b *Baseline, pkgs []string, findings []Finding) *Ratchet {
+	isAnalysed := lo.SliceToMap(pkgs, func(pkg string) (string, bool) {
+		return pkg, true
+	})
+
+	baselineEntries := lo.Filter(b.Entries, func(entry BaselineEntry, _ int) bool {
+		return isAnalysed[entry.Package]
+	})
+	keptEntries := lo.Reject(b.Entries, func(entry BaselineEntry, _ int) bool {
+		return isAnalysed[entry.Package]
+	})
+
+	counts := make(map[resolverKey]*ResolverCount)
+	count := func(pkg, resolver string) *ResolverCount {
+		key := resolverKey{pkg: pkg, resolver: resolver}
+
+		if _, ok := counts[key]; !ok {
+			counts[key] = &ResolverCount{Package: pkg, Resolver: resolver}
+		}
+
+		return counts[key]
+	}
+
+	lo.ForEach(baselineEntries, func(entry BaselineEntry, _ int) {
+		count(entry.Package, entry.Resolver).Baseline += entry.Count
+	})
+
+	lo.ForEach(findings, func(finding Finding, _ int) {
+		count(finding.Package, finding.Resolver).Current++
+	})
+
+	tolerated := make(map[string]int)
+	lo.ForEach(baselineEntries, func(entry BaselineEntry, _ int) {
+		tolerated[entry.Fingerprint] += entry.Count
+	})
+
+	current := NewBaseline(findings)
+	currentCounts := lo.SliceToMap(current.Entries, func(entry BaselineEntry) (string, int) {
+		return entry.Fingerprint, entry.Count
+	})
+	removed := false
+
+	// The shrunk baseline tolerates, for each fingerprint, the fewer of the baseline and the current findings.
+	shrunk := &Baseline{Entries: keptEntries}
+
+	lo.ForEach(current.Entries, func(entry BaselineEntry, _ int) {
+		if entry.Count > tolerated[entry.Fingerprint] {
+			count(entry.Package, entry.Resolver).New += entry.Count - tolerated[entry.Fingerprint]
+		}
+	})
+
+	lo.ForEach(baselineEntries, func(entry BaselineEntry, _ int) {
+		found := currentCounts[entry.Fingerprint]
+		if found < entry.Count {
+			removed = true
+		}
+
+		if entry.Count = lo.Min([]int{entry.Count, found}); entry.Count > 0 {
+			shrunk.Entries = append(shrunk.Entries, entry)
+		}
+	})
+
+	shrunk.sort()
+
+	resolverCounts := lo.MapToSlice(counts, func(_ resolverKey, c *ResolverCount) ResolverCount {
+		return *c
+	})
+	sort.Slice(resolverCounts, func(i, j int) bool {
+		if resolverCounts[i].Package != resolverCounts[j].Package {
+			return resolverCounts[i].Package < resolverCounts[j].Package
+		}
+
+		return resolverCounts[i].Resolver < resolverCounts[j].Resolver
+	})
+
+	packages := make(map[string]*PackageSummary)
+
+	lo.ForEach(resolverCounts, func(c ResolverCount, _ int) {
+		summary, ok := packages[c.Package]
+		if !ok {
+			summary = &PackageSummary{Package: c.Package}
+			packages[c.Package] = summary
+		}
+
+		summary.Baseline += c.Baseline
+		summary.Current += c.Current
+
+		if c.Current > 0 {
+			summary.Resolvers++
+		}
+	})
+
+	summaries := lo.MapToSlice(packages, func(_ string, summary *PackageSummary) PackageSummary {
+		return *summary
+	})
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Package < summaries[j].Package
+	})
+
+	return &Ratchet{
+		Gained: lo.Filter(resolverCounts, func(c ResolverCount, _ int) bool {
+			return c.New > 0
+		}),
+		Removed:  removed,
+		Packages: summaries,
+		Baseline: shrunk,
+	}
+}
@@ -0,0 +1,204 @@
+package forceloader
+
+import (
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lookupTypeName finds the type named like "a/b.Resolver" in pkg or in its transitive imports.
+func lookupTypeName(
+	pkg *types.Package,
+	qualified string,
+) *types.TypeName {
+	i := strings.LastIndex(qualified, ".")
+	if i < 0 {
+		return nil
+	}
+
+	path, name := qualified[:i], qualified[i+1:]
+
+	var obj *types.TypeName
+
+	walkImports(pkg, func(pkg *types.Package) bool {
+		if pkg.Path() != path {
+			return false
+		}
+
+		obj, _ = pkg.Scope().Lookup(name).(*types.TypeName)
+
+		return true
+	})
+
+	return obj
+}
+
+// importsPackage reports whether pkg is path or imports it, directly or not, vendored or not.
+func importsPackage(
+	pkg *types.Package,
+	path string,
+) bool {
+	found := false
+
+	walkImports(pkg, func(pkg *types.Package) bool {
+		found = unvendoredPath(pkg.Path()) == path
+
+		return found
+	})
+
+	return found
+}
+
+// unvendoredPath returns the import path of a package vendored at path, or path itself.
+func unvendoredPath(path string) string {
+	if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+		return path[i+len("/vendor/"):]
+	}
+
+	return strings.TrimPrefix(path, "vendor/")
+}
+
+// walkImports calls f for pkg and its transitive imports until f returns true.
+func walkImports(
+	pkg *types.Package,
+	f func(pkg *types.Package) bool,
+) {
+	seen := make(map[*types.Package]bool)
+
+	var walk func(pkg *types.Package) bool
+
+	walk = func(pkg *types.Package) bool {
+		if seen[pkg] {
+			return false
+		}
+
+		seen[pkg] = true
+
+		if f(pkg) {
+			return true
+		}
+
+		for _, imp := range pkg.Imports() {
+			if walk(imp) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	walk(pkg)
+}
+
+// derefNamed strips pointers from t and returns the named type underneath, if any.
+func derefNamed(t types.Type) (*types.Named, bool) {
+	for {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			break
+		}
+
+		t = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+
+	return named, ok
+}
+
+// embedsType reports whether the struct underlying named embeds target,
+// directly or through other embedded structs, as a value or a pointer.
+func embedsType(
+	named *types.Named,
+	target *types.TypeName,
+) bool {
+	seen := make(map[*types.Named]bool)
+
+	var embeds func(named *types.Named) bool
+
+	embeds = func(named *types.Named) bool {
+		if seen[named] {
+			return false
+		}
+
+		seen[named] = true
+
+		str, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+
+		for i := 0; i < str.NumFields(); i++ {
+			field := str.Field(i)
+			if !field.Embedded() {
+				continue
+			}
+
+			embedded, ok := derefNamed(field.Type())
+			if !ok {
+				continue
+			}
+
+			if embedded.Origin().Obj() == target || embeds(embedded) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return embeds(named)
+}
+
+// mayContainResolvers reports whether any variable of the package, including receivers,
+// parameters and captured locals, holds a resolver or a root listed in ignoreResolverStructs,
+// or whether the package annotates any function or type as one.
+func mayContainResolvers(
+	pass *analysis.Pass,
+	isResolverType func(named *types.Named) bool,
+	annotations *resolverAnnotations,
+) bool {
+	if len(annotations.resolverFuncs) > 0 || len(annotations.resolverTypes) > 0 || len(annotations.rootFuncs) > 0 || len(annotations.rootTypes) > 0 {
+		return true
+	}
+
+	if isResolverType == nil {
+		return false
+	}
+
+	resolverTypes := make(map[*types.Named]bool)
+
+	for _, obj := range pass.TypesInfo.Defs {
+		v, ok := obj.(*types.Var)
+		if !ok || v.IsField() {
+			continue
+		}
+
+		named, ok := derefNamed(v.Type())
+		if !ok {
+			continue
+		}
+
+		is, ok := resolverTypes[named]
+		if !ok {
+			is = isResolverType(named)
+			resolverTypes[named] = is
+		}
+
+		if is {
+			return true
+		}
+	}
+
+	return false
+}
+
+// qualifiedName returns the name of obj like "a/b.Resolver", without type arguments.
+func qualifiedName(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+
+	return obj.Pkg().Path() + "." + obj.Name()
+}
@@ -0,0 +1,69 @@
+package forceloader
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+const rulesURL = "https://github.com/flum1025/forceloader/blob/main/docs/rules/"
+
+// Rule is a check of the analyzer, identified by a stable ID.
+type Rule struct {
+	ID   string
+	Name string
+	// Disabled tells that the rule runs only when it is enabled explicitly.
+	Disabled bool
+	// Severity is the severity of the rule unless the configuration overrides it.
+	Severity Severity
+}
+
+var (
+	RestrictedCall     = Rule{ID: "FL001", Name: "restricted-call", Severity: SeverityError}
+	RestrictedEscape   = Rule{ID: "FL002", Name: "restricted-escape", Disabled: true, Severity: SeverityError}
+	UnusedSuppression  = Rule{ID: "FL003", Name: "unused-suppression", Severity: SeverityError}
+	InvalidSuppression = Rule{ID: "FL004", Name: "invalid-suppression", Severity: SeverityError}
+	ExpiredSuppression = Rule{ID: "FL005", Name: "expired-suppression", Severity: SeverityError}
+	StaleBaseline      = Rule{ID: "FL006", Name: "stale-baseline", Severity: SeverityError}
+	Misconfiguration   = Rule{ID: "FL007", Name: "misconfiguration", Severity: SeverityWarning}
+	RestrictedLoop     = Rule{ID: "FL008", Name: "restricted-call-in-loop", Severity: SeverityError}
+	EagerField         = Rule{ID: "FL009", Name: "eager-field", Severity: SeverityWarning}
+	PureFunction       = Rule{ID: "FL010", Name: "restricted-call-in-pure-function", Severity: SeverityError}
+	SharedWrite        = Rule{ID: "FL011", Name: "shared-state-write", Severity: SeverityError}
+)
+
+// Rules are all the rules of the analyzer, ordered by ID.
+var Rules = []Rule{
+	RestrictedCall,
+	RestrictedEscape,
+	UnusedSuppression,
+	InvalidSuppression,
+	ExpiredSuppression,
+	StaleBaseline,
+	Misconfiguration,
+	RestrictedLoop,
+	EagerField,
+	PureFunction,
+	SharedWrite,
+}
+
+// URL returns the documentation page of the rule.
+func (r Rule) URL() string {
+	return rulesURL + r.ID + ".md"
+}
+
+func (r Rule) String() string {
+	return r.ID + " " + r.Name
+}
+
+// LookupRule finds the rule by its ID or its name.
+func LookupRule(key string) (Rule, error) {
+	rule, ok := lo.Find(Rules, func(rule Rule) bool {
+		return rule.ID == key || rule.Name == key
+	})
+	if !ok {
+		return Rule{}, fmt.Errorf("unknown rule %q", key)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,149 @@
+package forceloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// graphqlSchema holds the parts of the GraphQL schema the analyzer needs.
+type graphqlSchema struct {
+	// fields holds the normalized names of the fields of every object and interface type.
+	fields map[string]bool
+	// operations holds the normalized names of the fields of the root types by operation.
+	operations map[ast.Operation]map[string]bool
+	// objects holds the names of the fields of the object types other than the root types, by type name.
+	objects map[string][]string
+}
+
+// schemas caches the schemas by their patterns, as every package of a run loads the same files.
+var schemas sync.Map
+
+type schemaResult struct {
+	once   sync.Once
+	schema *graphqlSchema
+	err    error
+}
+
+// loadSchema parses the GraphQL files matching the glob patterns.
+func loadSchema(patterns []string) (*graphqlSchema, error) {
+	value, _ := schemas.LoadOrStore(strings.Join(patterns, "\x00"), &schemaResult{})
+	result := value.(*schemaResult)
+
+	result.once.Do(func() {
+		result.schema, result.err = parseSchema(patterns)
+	})
+
+	return result.schema, result.err
+}
+
+func parseSchema(patterns []string) (*graphqlSchema, error) {
+	sources := make([]*ast.Source, 0)
+
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema pattern %q: %w", pattern, err)
+		}
+
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("schema %q matches no files", pattern)
+		}
+
+		for _, path := range paths {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read schema: %w", err)
+			}
+
+			sources = append(sources, &ast.Source{Name: path, Input: string(src)})
+		}
+	}
+
+	doc, err := parser.ParseSchemas(sources...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	roots := map[ast.Operation]string{
+		ast.Query:        "Query",
+		ast.Mutation:     "Mutation",
+		ast.Subscription: "Subscription",
+	}
+
+	for _, schema := range append(doc.Schema, doc.SchemaExtension...) {
+		for _, operationType := range schema.OperationTypes {
+			roots[operationType.Operation] = operationType.Type
+		}
+	}
+
+	s := &graphqlSchema{
+		fields:     make(map[string]bool),
+		operations: make(map[ast.Operation]map[string]bool),
+		objects:    make(map[string][]string),
+	}
+
+	isRoot := lo.Invert(roots)
+
+	for _, def := range append(doc.Definitions, doc.Extensions...) {
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			continue
+		}
+
+		for _, field := range def.Fields {
+			name := normalizeFieldName(field.Name)
+			s.fields[name] = true
+
+			for operation, root := range roots {
+				if def.Name != root {
+					continue
+				}
+
+				if s.operations[operation] == nil {
+					s.operations[operation] = make(map[string]bool)
+				}
+
+				s.operations[operation][name] = true
+			}
+
+			if _, ok := isRoot[def.Name]; def.Kind == ast.Object && !ok {
+				s.objects[def.Name] = append(s.objects[def.Name], field.Name)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// normalizeFieldName folds a field or method name the way graph-gophers/graphql-go matches them.
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// HasField reports whether any object or interface type has a field resolved by the method name.
+func (s *graphqlSchema) HasField(name string) bool {
+	return s.fields[normalizeFieldName(name)]
+}
+
+// RootKind returns the kind of the root resolver method name, which is a query unless
+// the schema declares it on the mutation or subscription type.
+func (s *graphqlSchema) RootKind(name string) resolverKind {
+	if s == nil {
+		return kindQuery
+	}
+
+	switch name = normalizeFieldName(name); {
+	case s.operations[ast.Mutation][name]:
+		return kindMutation
+	case s.operations[ast.Subscription][name]:
+		return kindSubscription
+	default:
+		return kindQuery
+	}
+}
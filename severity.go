@@ -0,0 +1,49 @@
+package forceloader
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Severity is how serious the diagnostics of a rule are.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+func parseSeverity(s string) (Severity, error) {
+	severity := Severity(strings.TrimSpace(s))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q, must be one of error, warning or info", s)
+	}
+
+	return severity, nil
+}
+
+// Less reports whether s is less serious than other.
+func (s Severity) Less(other Severity) bool {
+	return severityRanks[s] < severityRanks[other]
+}
+
+// How diagnostics below SeverityError are handled, as go vet fails on any diagnostic.
+const (
+	// warningsReport reports them as diagnostics like errors.
+	warningsReport = "report"
+	// warningsPrint prints them to stderr without failing.
+	warningsPrint = "print"
+	// warningsDrop discards them.
+	warningsDrop = "drop"
+)
+
+var stderr io.Writer = os.Stderr
@@ -0,0 +1,61 @@
+package annotation
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+//forceloader:root
+type queryResolver struct{ *Resolver }
+
+type todoResolver struct{ *Resolver }
+
+func (r *queryResolver) Todos(ctx context.Context) error {
+	return r.UseCase.Fuga()
+}
+
+// Owner is resolved eagerly by the parent.
+//
+//forceloader:root
+func (r *todoResolver) Owner(ctx context.Context) error {
+	err := r.UseCase.Fuga()
+
+	func() {
+		r.UseCase.Fuga()
+	}()
+
+	return err
+}
+
+type User struct {
+	ID string
+}
+
+// userResolver follows graph-gophers/graphql-go and wraps the model.
+//
+//forceloader:resolver
+type userResolver struct {
+	u       *User
+	useCase usecase.UseCase
+}
+
+func (r userResolver) Name(ctx context.Context) error {
+	err := r.useCase.Fuga() // want `r\.useCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(a/annotation\.userResolver\)\.Name`
+
+	return err
+}
+
+type userLoader interface {
+	UseCase() usecase.UseCase
+}
+
+//forceloader:resolver
+func loadUser(ctx context.Context, l userLoader) error {
+	err := l.UseCase().Fuga() // want `l\.UseCase\(\)\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/annotation\.loadUser`
+
+	return err
+}
@@ -0,0 +1,50 @@
+package autobind
+
+import (
+	"a/usecase"
+	"context"
+)
+
+var uc usecase.UseCase
+
+type Todo struct {
+	ID     string
+	Text   string
+	UserID string
+}
+
+func (t *Todo) User(ctx context.Context) (*Account, error) {
+	if err := uc.Fuga(); err != nil { // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/autobind\.Todo\)\.User`
+		return nil, err
+	}
+
+	return &Account{ID: t.UserID}, nil
+}
+
+// Validate resolves no field of the schema, so it is not a resolver.
+func (t *Todo) Validate() error { // want Validate:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return uc.Fuga()
+}
+
+type Account struct {
+	ID   string
+	Name string
+}
+
+func (a *Account) Label() (string, error) {
+	return a.Name, uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/autobind\.Account\)\.Label`
+}
+
+// Friends is resolved by a resolver, as gqlgen.yml sets resolver: true.
+func (a *Account) Friends() ([]*Account, error) { // want Friends:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return nil, uc.Fuga()
+}
+
+type User struct {
+	ID string
+}
+
+// User is bound to Account, so autobind does not bind the User type.
+func (u *User) Name() (string, error) { // want Name:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return "", uc.Fuga()
+}
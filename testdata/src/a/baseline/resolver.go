@@ -0,0 +1,34 @@
+package baseline // want `stale baseline entry 599d68c418fd969f: return r\.UseCase\.Fuga\(\) in \(\*a/baseline\.todoResolver\)\.Removed no longer matches`
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+	calls   int
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r *todoResolver) Text(ctx context.Context) error {
+	r.UseCase.Fuga()
+	r.UseCase.Fuga()
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/baseline\.todoResolver\)\.Text`
+
+	return nil
+}
+
+func (r *todoResolver) User(ctx context.Context) error {
+	err := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/baseline\.todoResolver\)\.User`
+
+	return err
+}
+
+func (r *todoResolver) Count(ctx context.Context) error {
+	r.calls++
+	r.calls++ // want `\(\*a/baseline\.todoResolver\)\.Count writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package boundary
+
+import (
+	"a/usecase"
+	"net/http"
+)
+
+func NewTodosHandler(uc usecase.UseCase, ids []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := uc.Fuga(); err != nil {
+			return
+		}
+
+		for range ids {
+			uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in a/boundary\.NewTodosHandler\$1`
+		}
+	}
+}
+
+func NewPrefetchHandler(uc usecase.UseCase, ids []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		go uc.Fuga()
+
+		go func() {
+			for range ids {
+				uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in a/boundary\.NewPrefetchHandler\$1\$1`
+			}
+		}()
+	}
+}
+
+func Fetch(uc usecase.UseCase, ids []string) { // want Fetch:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	for range ids {
+		uc.Fuga()
+	}
+}
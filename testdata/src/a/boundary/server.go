@@ -0,0 +1,56 @@
+package boundary
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type TodoServer interface {
+	ListTodos(ctx context.Context, ids []string) error
+}
+
+type server struct {
+	uc usecase.UseCase
+}
+
+var _ TodoServer = (*server)(nil)
+
+func (s *server) ListTodos(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := s.loadUser(id); err != nil { // want `s\.loadUser \(a/usecase\.UseCase\.Fuga through \(\*a/boundary\.server\)\.loadUser\) cannot be used in a loop in \(\*a/boundary\.server\)\.ListTodos`
+			return err
+		}
+	}
+
+	return s.loadUser("")
+}
+
+func (s *server) loadUser(id string) error {
+	return s.fetch()
+}
+
+func (s *server) fetch() error {
+	return s.uc.Fuga()
+}
+
+type Syncer struct {
+	uc usecase.UseCase
+}
+
+//forceloader:boundary
+func (s *Syncer) Sync(ids []string) {
+	for range ids {
+		s.uc.Fuga() //nolint:forceloader // syncing is a batch job
+	}
+}
+
+//forceloader:boundary
+type Importer struct {
+	uc usecase.UseCase
+}
+
+func (i *Importer) Import(ids []string) {
+	for range ids {
+		i.uc.Fuga() // want `i\.uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/boundary\.Importer\)\.Import`
+	}
+}
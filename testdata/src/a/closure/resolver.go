@@ -0,0 +1,49 @@
+package closure
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type queryResolver struct{ *Resolver }
+
+type todoResolver struct{ *Resolver }
+
+func goFunc(f func() error) {
+	_ = f()
+}
+
+func (r *todoResolver) User(ctx context.Context) error {
+	func() {
+		func() {
+			r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/closure\.todoResolver\)\.User\$1\$1`
+		}()
+	}()
+
+	useCase := r.UseCase
+	func() {
+		useCase.Fuga() // want `useCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/closure\.todoResolver\)\.User\$2`
+	}()
+
+	f := r.UseCase.Fuga // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/closure\.todoResolver\)\.User`
+	goFunc(f)
+
+	goFunc(r.UseCase.Fuga) // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/closure\.todoResolver\)\.User`
+
+	return nil
+}
+
+func (r *queryResolver) Todos(ctx context.Context) error {
+	useCase := r.UseCase
+	func() {
+		useCase.Fuga()
+	}()
+
+	goFunc(r.UseCase.Fuga)
+
+	return nil
+}
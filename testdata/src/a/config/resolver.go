@@ -0,0 +1,20 @@
+package config // want `resolverStruct a/config\.Resolvr is not declared in a/config`
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+type Todo struct {
+	ID string
+}
+
+func (r *todoResolver) User(ctx context.Context, obj *Todo) error { // want User:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return r.UseCase.Fuga()
+}
@@ -0,0 +1,83 @@
+package directive
+
+import (
+	"a/usecase"
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+func NewConfig(r *Resolver) Config {
+	c := Config{
+		Resolvers: r,
+		Directives: DirectiveRoot{
+			HasRole: hasRole(r.UseCase),
+			Auth:    r.auth,
+		},
+	}
+
+	c.Directives.Cached = func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+		if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/directive\.NewConfig\$1`
+			return nil, err
+		}
+
+		return next(ctx)
+	}
+
+	return c
+}
+
+func hasRole(uc usecase.UseCase) func(ctx context.Context, obj interface{}, next graphql.Resolver, role string) (interface{}, error) {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver, role string) (interface{}, error) {
+		if err := uc.Fuga(); err != nil { // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/directive\.hasRole\$1`
+			return nil, err
+		}
+
+		return next(ctx)
+	}
+}
+
+func (r *Resolver) auth(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/directive\.Resolver\)\.auth`
+		return nil, err
+	}
+
+	return next(ctx)
+}
+
+func NewServer(r *Resolver) *handler.Server {
+	srv := handler.New(nil)
+
+	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/directive\.NewServer\$1`
+			return nil, err
+		}
+
+		return next(ctx)
+	})
+
+	srv.AroundResponses(logResponse(r))
+
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		r.UseCase.Fuga()
+
+		return next(ctx)
+	})
+
+	return srv
+}
+
+func logResponse(r *Resolver) graphql.ResponseMiddleware {
+	return r.logResponse
+}
+
+func (r *Resolver) logResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/directive\.Resolver\)\.logResponse`
+
+	return next(ctx)
+}
@@ -0,0 +1,21 @@
+package loader
+
+import (
+	"a/eager/model"
+	"context"
+)
+
+type Loaders struct {
+	UserByID *UserLoader
+}
+
+// UserLoader batches the users loaded by the field resolvers of a request.
+type UserLoader struct{}
+
+func (l *UserLoader) Load(ctx context.Context, id string) (*model.User, error) {
+	return &model.User{ID: id}, nil
+}
+
+func For(ctx context.Context) *Loaders {
+	return &Loaders{UserByID: &UserLoader{}}
+}
@@ -0,0 +1,12 @@
+package model
+
+type Todo struct {
+	ID     string
+	UserID string
+	User   *User
+}
+
+type User struct {
+	ID   string
+	Name string
+}
@@ -0,0 +1,63 @@
+package eager
+
+import (
+	"a/eager/loader"
+	"a/eager/model"
+	"a/eager/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Todos(ctx context.Context) ([]*model.Todo, error) {
+	todos, err := r.UseCase.ListTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, todo := range todos {
+		user, err := r.UseCase.GetUser(ctx, todo.UserID) // want `r\.UseCase\.GetUser \(a/eager/usecase\.UseCase\.GetUser\) cannot be used in a loop in \(\*a/eager\.queryResolver\)\.Todos`
+		if err != nil {
+			return nil, err
+		}
+
+		todo.User = user // want `Todo\.user is fetched for each item by \(\*a/eager\.queryResolver\)\.Todos with a/eager/usecase\.UseCase\.GetUser; resolve it with a loader in a field resolver, forced with @goField\(forceResolver: true\)`
+	}
+
+	return todos, nil
+}
+
+// BatchedTodos leaves Todo.user to todoResolver.User, which loads the users of all todos at once.
+func (r *queryResolver) BatchedTodos(ctx context.Context) ([]*model.Todo, error) {
+	return r.UseCase.ListTodos(ctx)
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r *todoResolver) User(ctx context.Context, obj *model.Todo) (*model.User, error) {
+	return loader.For(ctx).UserByID.Load(ctx, obj.UserID)
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) CreateTodos(ctx context.Context, userIDs []string) ([]*model.Todo, error) {
+	todos := make([]*model.Todo, 0, len(userIDs))
+
+	for _, id := range userIDs {
+		user, err := r.UseCase.GetUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, &model.Todo{
+			UserID: id,
+			User:   user, // want `Todo\.user is fetched for each item by \(\*a/eager\.mutationResolver\)\.CreateTodos with a/eager/usecase\.UseCase\.GetUser; resolve it with a loader in a field resolver, forced with @goField\(forceResolver: true\)`
+		})
+	}
+
+	return todos, nil
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"a/eager/model"
+	"context"
+)
+
+type UseCase interface {
+	ListTodos(ctx context.Context) ([]*model.Todo, error)
+	GetUser(ctx context.Context, id string) (*model.User, error)
+}
@@ -0,0 +1,61 @@
+package embedding
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+func (r *Resolver) Todo() error { // want Todo:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return r.UseCase.Fuga()
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r todoResolver) Text(ctx context.Context) error {
+	err := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(a/embedding\.todoResolver\)\.Text`
+
+	func() {
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(a/embedding\.todoResolver\)\.Text\$1`
+	}()
+
+	return err
+}
+
+type baseResolver struct {
+	*Resolver
+}
+
+type userResolver struct {
+	baseResolver
+}
+
+func (r *userResolver) Name(ctx context.Context) error {
+	err := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/embedding\.userResolver\)\.Name`
+
+	return err
+}
+
+type pagedResolver[T any] struct {
+	*Resolver
+	items []T
+}
+
+func (r *pagedResolver[T]) TotalCount(ctx context.Context) error {
+	err := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/embedding\.pagedResolver\[T\]\)\.TotalCount`
+
+	return err
+}
+
+type wrappedResolver struct {
+	pagedResolver[string]
+}
+
+func (r *wrappedResolver) Count(ctx context.Context) error {
+	err := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/embedding\.wrappedResolver\)\.Count`
+
+	return err
+}
@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type View struct {
+	UseCase usecase.UseCase
+}
+
+func Render(ctx context.Context, useCase usecase.UseCase, obj any) error {
+	return useCase.Fuga()
+}
@@ -0,0 +1,43 @@
+package escape
+
+import (
+	"a/escape/presenter"
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+type Todo struct {
+	ID string
+}
+
+func (r *todoResolver) Text(ctx context.Context, obj *Todo) error {
+	err := presenter.Render(ctx, r.UseCase, obj) // want `a/usecase\.UseCase escapes \(\*a/escape\.todoResolver\)\.Text via argument of a/escape/presenter\.Render`
+
+	return err
+}
+
+func (r *todoResolver) View(ctx context.Context, obj *Todo) (*presenter.View, error) {
+	view := &presenter.View{UseCase: r.UseCase} // want `a/usecase\.UseCase escapes \(\*a/escape\.todoResolver\)\.View via struct field a/escape/presenter\.View\.UseCase`
+
+	return view, nil
+}
+
+func (r *todoResolver) Dependency(ctx context.Context, obj *Todo) (usecase.UseCase, error) {
+	return r.Resolver.UseCase, nil // want `a/usecase\.UseCase escapes \(\*a/escape\.todoResolver\).Dependency via return value`
+}
+
+func (r *todoResolver) Send(ctx context.Context, ch chan<- usecase.UseCase) {
+	ch <- r.Resolver.UseCase // want `a/usecase\.UseCase escapes \(\*a/escape\.todoResolver\)\.Send via channel send`
+}
+
+func (r *todoResolver) Loaded(ctx context.Context, obj *Todo) error {
+	err := presenter.Render(ctx, r.Resolver.UseCase, obj) //nolint:forceloader // rendering does not fetch data
+
+	return err
+}
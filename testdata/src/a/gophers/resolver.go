@@ -0,0 +1,83 @@
+package gophers
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	uc usecase.UseCase
+}
+
+type Todo struct {
+	ID   string
+	Text string
+}
+
+func (r *Resolver) Todos(ctx context.Context) ([]*todoResolver, error) {
+	if err := r.uc.Fuga(); err != nil {
+		return nil, err
+	}
+
+	return []*todoResolver{{todo: &Todo{}, uc: r.uc}}, nil
+}
+
+func (r *Resolver) Todo(ctx context.Context, args struct{ ID string }) (*todoResolver, error) {
+	if err := r.uc.Fuga(); err != nil {
+		return nil, err
+	}
+
+	return &todoResolver{todo: &Todo{ID: args.ID}, uc: r.uc}, r.uc.Fuga() // want `r\.uc\.Fuga \(a/usecase\.UseCase\.Fuga\) exceeds the restricted calls allowed in \(\*a/gophers\.Resolver\)\.Todo`
+}
+
+func (r *Resolver) CreateTodos(ctx context.Context, args struct{ Texts []string }) ([]*todoResolver, error) {
+	todos := make([]*todoResolver, 0, len(args.Texts))
+
+	for _, text := range args.Texts {
+		if err := r.uc.Fuga(); err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, &todoResolver{todo: &Todo{Text: text}, uc: r.uc})
+	}
+
+	return todos, nil
+}
+
+type todoResolver struct {
+	todo *Todo
+	uc   usecase.UseCase
+}
+
+func (r *todoResolver) ID() string {
+	return r.todo.ID
+}
+
+func (r *todoResolver) Text() string {
+	return r.todo.Text
+}
+
+func (r *todoResolver) User(ctx context.Context) (*userResolver, error) {
+	if err := r.uc.Fuga(); err != nil { // want `r\.uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/gophers\.todoResolver\)\.User`
+		return nil, err
+	}
+
+	return &userResolver{uc: r.uc}, nil
+}
+
+// Refresh resolves no field of the schema, so it is not a resolver.
+func (r *todoResolver) Refresh() error { // want Refresh:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	return r.uc.Fuga()
+}
+
+func (r *todoResolver) load() error {
+	return r.uc.Fuga()
+}
+
+type userResolver struct {
+	uc usecase.UseCase
+}
+
+func (r *userResolver) Name() (string, error) {
+	return "", r.uc.Fuga() // want `r\.uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/gophers\.userResolver\)\.Name`
+}
@@ -0,0 +1,101 @@
+package graphqlgo
+
+import (
+	"a/usecase"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Todo struct {
+	ID   string
+	Text string
+}
+
+func NewSchema(uc usecase.UseCase) (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "", uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in User\.name`
+				},
+			},
+		},
+	})
+
+	todoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Todo",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"text": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*Todo).Text, nil
+					},
+				},
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if err := uc.Fuga(); err != nil { // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in Todo\.user`
+							return nil, err
+						}
+
+						return struct{}{}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"todos": &graphql.Field{
+				Type: graphql.NewList(todoType),
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					ids, _ := p.Args["ids"].([]interface{})
+					todos := make([]*Todo, 0, len(ids))
+
+					for range ids {
+						if err := uc.Fuga(); err != nil { // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in Query\.todos`
+							return nil, err
+						}
+
+						todos = append(todos, &Todo{})
+					}
+
+					return todos, uc.Fuga()
+				},
+			},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTodos": &graphql.Field{
+				Type: graphql.NewList(todoType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					texts, _ := p.Args["texts"].([]interface{})
+
+					for range texts {
+						if err := uc.Fuga(); err != nil {
+							return nil, err
+						}
+					}
+
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+}
@@ -0,0 +1,11 @@
+//forceloader:ignore
+
+package ignore
+
+import "context"
+
+func (r *todoResolver) Done(ctx context.Context) error {
+	r.UseCase.Fuga()
+
+	return nil
+}
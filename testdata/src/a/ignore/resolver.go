@@ -0,0 +1,79 @@
+package ignore
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+//forceloader:ignore
+type viewerResolver struct{ *Resolver }
+
+type userResolver struct{ *Resolver } //forceloader:ignore
+
+func (r *todoResolver) Text(ctx context.Context) error {
+	//forceloader:ignore
+	r.UseCase.Fuga()
+	r.UseCase.Fuga() //forceloader:ignore
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/ignore\.todoResolver\)\.Text`
+
+	r.UseCase.Fuga() //forceloader:ignore until=2099-12-31 owner=@team-todo reason="waiting for the user loader"
+
+	// want +1 `forceloader:ignore directive expired on 2026-10-17 \(owner @team-todo\)` `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/ignore\.todoResolver\)\.Text`
+	r.UseCase.Fuga() //forceloader:ignore until=2026-10-17 owner=@team-todo reason="waiting for the user loader"
+	r.UseCase.Fuga() //forceloader:ignore until=2026-10-18 owner=@team-todo
+
+	// want +1 `invalid forceloader:ignore directive: until must be a date like 2006-01-02: "tomorrow"` `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/ignore\.todoResolver\)\.Text`
+	r.UseCase.Fuga() //forceloader:ignore until=tomorrow
+
+	return nil
+}
+
+// User is the resolver for the user field.
+//
+//forceloader:ignore
+func (r *todoResolver) User(ctx context.Context) error {
+	r.UseCase.Fuga()
+
+	func() {
+		r.UseCase.Fuga()
+	}()
+
+	return nil
+}
+
+func (r *viewerResolver) Name(ctx context.Context) error { // want Name:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	r.UseCase.Fuga()
+
+	return nil
+}
+
+func (r *userResolver) Name(ctx context.Context) error { // want Name:`restrictedHelper\(a/usecase\.UseCase\.Fuga\)`
+	r.UseCase.Fuga()
+
+	return nil
+}
+
+// want +2 `forceloader:ignore directive expired on 2026-10-01`
+//
+//forceloader:ignore until=2026-10-01
+func (r *todoResolver) Title(ctx context.Context) error {
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/ignore\.todoResolver\)\.Title`
+
+	return nil
+}
+
+// want +1 `invalid forceloader:ignore directive: unknown argument "ownr"`
+//forceloader:ignore ownr=@team-todo
+type adminResolver struct{ *Resolver }
+
+func (r *adminResolver) Name(ctx context.Context) error {
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/ignore\.adminResolver\)\.Name`
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+)
+
+func (r *queryResolver) Message(ctx context.Context) (string, error) {
+	if err := r.UseCase.Fuga(); err != nil {
+		return err.Error(), nil
+	}
+
+	return describe(fmt.Errorf("no message")), nil
+}
+
+func describe(err error) string {
+	return err.Error()
+}
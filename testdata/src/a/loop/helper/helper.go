@@ -0,0 +1,15 @@
+package helper
+
+import "a/usecase"
+
+func Check(uc usecase.UseCase) error {
+	return check(uc)
+}
+
+func check(uc usecase.UseCase) error {
+	return uc.Fuga()
+}
+
+func Noop(uc usecase.UseCase) error {
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package loop
+
+import (
+	"context"
+	"iter"
+)
+
+type IterUseCase interface {
+	All() iter.Seq[*Todo]
+}
+
+type iterResolver struct {
+	*queryResolver
+	Iter IterUseCase
+}
+
+func (r *iterResolver) Todos(ctx context.Context) ([]*Todo, error) {
+	for range r.Iter.All() {
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/loop\.iterResolver\)\.Todos\$1`
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,119 @@
+package loop
+
+import (
+	"a/loop/helper"
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+	Todos   TodoUseCase
+}
+
+type Todo struct {
+	ID     string
+	UserID string
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Todos(ctx context.Context) ([]*Todo, error) {
+	todos, err := r.Resolver.Todos.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for range todos {
+		if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/loop\.queryResolver\)\.Todos`
+			return nil, err
+		}
+	}
+
+	return todos, nil
+}
+
+func (r *queryResolver) Checks(ctx context.Context) ([]*Todo, error) {
+	todos, err := r.Resolver.Todos.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for range todos {
+		if err := helper.Check(r.UseCase); err != nil { // want `helper\.Check \(a/usecase\.UseCase\.Fuga through a/loop/helper\.Check\) cannot be used in a loop in \(\*a/loop\.queryResolver\)\.Checks`
+			return nil, err
+		}
+
+		if err := helper.Noop(r.UseCase); err != nil {
+			return nil, err
+		}
+	}
+
+	return todos, nil
+}
+
+func (r *queryResolver) Users(ctx context.Context) ([]*Todo, error) {
+	todos, err := r.Resolver.Todos.List()
+	if err != nil {
+		return nil, err
+	}
+
+	forEach(todos, func(todo *Todo) {
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/loop\.queryResolver\)\.Users\$1`
+	})
+
+	r.Resolver.Todos.Each(func(todo *Todo) {
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/loop\.queryResolver\)\.Users\$2`
+	})
+
+	func() {
+		r.UseCase.Fuga()
+	}()
+
+	return todos, nil
+}
+
+func (r *queryResolver) Save(ctx context.Context) error {
+	return r.Resolver.Todos.WithTx(func() error {
+		return r.UseCase.Fuga()
+	})
+}
+
+func (r *queryResolver) Prefetch(ctx context.Context, ids []string) error {
+	go func() {
+		for range ids {
+			r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/loop\.queryResolver\)\.Prefetch\$1`
+		}
+	}()
+
+	return nil
+}
+
+func (r *queryResolver) Todo(ctx context.Context) (*Todo, error) {
+	return &Todo{}, r.UseCase.Fuga()
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) DeleteTodos(ctx context.Context, ids []string) error {
+	for range ids {
+		if err := r.UseCase.Fuga(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//forceloader:root
+func Preload(r *Resolver, ids []string) {
+	for range ids {
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in a/loop\.Preload`
+	}
+}
+
+func forEach[T any](items []T, f func(item T)) {
+	for _, item := range items {
+		f(item)
+	}
+}
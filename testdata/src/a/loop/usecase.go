@@ -0,0 +1,7 @@
+package loop
+
+type TodoUseCase interface {
+	List() ([]*Todo, error)
+	Each(f func(todo *Todo))
+	WithTx(f func() error) error
+}
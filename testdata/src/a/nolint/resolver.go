@@ -0,0 +1,41 @@
+package nolint
+
+import (
+	"a/loader"
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	Loader  loader.Loader
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r *todoResolver) User(ctx context.Context) error {
+	// nolint
+	r.UseCase.Fuga()
+	r.UseCase.Fuga() // nolint
+
+	//nolint:forceloader // the user is cached by the caller
+	r.UseCase.Fuga()
+	r.UseCase.Fuga() //nolint:forceloader,hoge // the user is cached by the caller
+
+	// want +1 `nolint:forceloader directive requires a reason`
+	r.UseCase.Fuga() //nolint:forceloader
+
+	// this comment mentions nolint but is not a directive
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/nolint\.todoResolver\)\.User`
+	r.UseCase.Fuga() //nolint:hoge // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/nolint\.todoResolver\)\.User`
+
+	// want +1 `unused nolint:forceloader directive`
+	r.Loader.Hoge() //nolint:forceloader // loaders are allowed anyway
+
+	return nil
+}
+
+// The directive stays in use while restricted-escape is disabled.
+func (r *todoResolver) Dependency(ctx context.Context) (usecase.UseCase, error) {
+	return r.UseCase, nil //nolint:forceloader // handed to the presenter
+}
@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type Todo struct {
+	ID string
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Todo(ctx context.Context) (*Todo, error) {
+	if err := r.UseCase.Fuga(); err != nil {
+		return nil, err
+	}
+
+	return &Todo{}, r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) exceeds the restricted calls allowed in \(\*a/policy\.queryResolver\)\.Todo`
+}
+
+func (r *queryResolver) Search(ctx context.Context, id string) (*Todo, error) {
+	if id == "" {
+		return &Todo{}, r.UseCase.Fuga()
+	}
+
+	return &Todo{ID: id}, r.UseCase.Fuga()
+}
+
+func (r *queryResolver) Todos(ctx context.Context, ids []string) ([]*Todo, error) {
+	todos := make([]*Todo, 0, len(ids))
+
+	for _, id := range ids {
+		if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/policy\.queryResolver\)\.Todos`
+			return nil, err
+		}
+
+		todos = append(todos, &Todo{ID: id})
+	}
+
+	return todos, nil
+}
+
+func (r *queryResolver) Each(ctx context.Context, ids []string) error {
+	for range ids {
+		func() {
+			r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/policy\.queryResolver\)\.Each\$1`
+		}()
+	}
+
+	return nil
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) CreateTodos(ctx context.Context, ids []string) error {
+	for range ids {
+		if err := r.UseCase.Fuga(); err != nil {
+			return err
+		}
+	}
+
+	go r.notify()
+	go r.UseCase.Fuga()
+
+	return r.UseCase.Fuga()
+}
+
+func (r *mutationResolver) notify() {
+	r.UseCase.Fuga()
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) TodoCreated(ctx context.Context) (<-chan *Todo, error) {
+	if err := r.UseCase.Fuga(); err != nil {
+		return nil, err
+	}
+
+	go r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be started in a goroutine by \(\*a/policy\.subscriptionResolver\)\.TodoCreated`
+
+	ch := make(chan *Todo)
+
+	go func() {
+		for {
+			r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/policy\.subscriptionResolver\)\.TodoCreated\$1, which runs in a goroutine`
+			ch <- &Todo{}
+		}
+	}()
+
+	return ch, r.UseCase.Fuga()
+}
+
+type entityResolver struct{ *Resolver }
+
+func (r *entityResolver) FindTodoByID(ctx context.Context, id string) (*Todo, error) {
+	if err := r.UseCase.Fuga(); err != nil {
+		return nil, err
+	}
+
+	return &Todo{ID: id}, r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) exceeds the restricted calls allowed in \(\*a/policy\.entityResolver\)\.FindTodoByID`
+}
+
+type viewerResolver struct{ *Resolver }
+
+func (r *viewerResolver) Viewer(ctx context.Context) (*Todo, error) {
+	return &Todo{}, r.UseCase.Fuga()
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r *todoResolver) Text(ctx context.Context, obj *Todo) (string, error) {
+	return "", r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/policy\.todoResolver\)\.Text`
+}
+
+func (r *todoResolver) Done(ctx context.Context, obj *Todo) (bool, error) {
+	defer r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a/policy\.todoResolver\)\.Done`
+
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package race
+
+import (
+	"context"
+	"sync"
+)
+
+var hits int
+
+type Resolver struct {
+	mu    sync.Mutex
+	rw    sync.RWMutex
+	calls int
+	cache map[string]*User
+}
+
+type Todo struct {
+	ID     string
+	UserID string
+	User   *User
+}
+
+type User struct {
+	ID string
+}
+
+type todoResolver struct{ *Resolver }
+
+func (r *todoResolver) User(ctx context.Context, obj *Todo) (*User, error) {
+	hits++ // want `\(\*a/race\.todoResolver\)\.User writes to package variable a/race\.hits, which field resolvers running concurrently share; guard the write with a lock`
+
+	r.calls++ // want `\(\*a/race\.todoResolver\)\.User writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+
+	user := &User{ID: obj.UserID}
+	r.cache[obj.UserID] = user // want `\(\*a/race\.todoResolver\)\.User writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+
+	obj.User = user // want `\(\*a/race\.todoResolver\)\.User writes to parent obj, which field resolvers running concurrently share; guard the write with a lock`
+
+	return user, nil
+}
+
+func (r *todoResolver) ID(ctx context.Context, obj *Todo) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	r.cache[obj.ID] = nil
+
+	return obj.ID, nil
+}
+
+func (r *todoResolver) Text(ctx context.Context, obj *Todo) (string, error) {
+	todo := &Todo{ID: obj.ID}
+	todo.UserID = obj.UserID
+
+	counts := map[string]int{}
+	counts[obj.ID]++
+
+	return todo.ID, nil
+}
+
+func (r *todoResolver) Done(ctx context.Context, obj *Todo) (bool, error) {
+	go func() {
+		hits = 0       // want `\(\*a/race\.todoResolver\)\.Done\$1 writes to package variable a/race\.hits, which field resolvers running concurrently share; guard the write with a lock`
+		r.calls++      // want `\(\*a/race\.todoResolver\)\.Done\$1 writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+		obj.User = nil // want `\(\*a/race\.todoResolver\)\.Done\$1 writes to parent obj, which field resolvers running concurrently share; guard the write with a lock`
+	}()
+
+	func(obj *Todo) {
+		obj.UserID = ""
+	}(&Todo{})
+
+	obj.ID = "" //nolint:forceloader // the todo is a copy made for this resolver
+
+	return false, nil
+}
+
+func (r *todoResolver) Title(ctx context.Context, obj *Todo) (string, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+
+	r.calls++ // want `\(\*a/race\.todoResolver\)\.Title writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+
+	hits++ // want `\(\*a/race\.todoResolver\)\.Title writes to package variable a/race\.hits, which field resolvers running concurrently share; guard the write with a lock`
+
+	return obj.ID, nil
+}
+
+func (r *todoResolver) Tags(ctx context.Context, obj *Todo) ([]string, error) {
+	r.mu.Lock()
+	defer func() { r.mu.Unlock() }()
+
+	var mu sync.Mutex
+	mu.Lock()
+	mu.Unlock()
+
+	r.calls++
+
+	return nil, nil
+}
+
+func (r *todoResolver) Views(ctx context.Context, obj *Todo) (int, error) {
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	r.calls++ // want `\(\*a/race\.todoResolver\)\.Views writes to receiver r, which field resolvers running concurrently share; guard the write with a lock`
+
+	return r.calls, nil
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Todos(ctx context.Context) ([]*Todo, error) {
+	r.calls++
+	hits++
+
+	return nil, nil
+}
@@ -0,0 +1,7 @@
+package deps
+
+import "a/usecase"
+
+type Deps struct {
+	UseCase usecase.UseCase
+}
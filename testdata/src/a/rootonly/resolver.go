@@ -0,0 +1,22 @@
+package rootonly
+
+import (
+	"context"
+
+	"a/rootonly/deps"
+)
+
+// queryResolver is a root listed in ignoreResolverStructs, without embedding the resolver struct.
+type queryResolver struct {
+	*deps.Deps
+}
+
+func (r *queryResolver) Todos(ctx context.Context, ids []string) error {
+	for range ids {
+		if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a loop in \(\*a/rootonly\.queryResolver\)\.Todos`
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package rules
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+type Todo struct {
+	ID string
+}
+
+func (r *todoResolver) User(ctx context.Context, obj *Todo) error {
+	r.UseCase.Fuga() //nolint:forceloader // the user is already cached
+
+	return nil
+}
+
+func (r *todoResolver) Dependency(ctx context.Context, obj *Todo) (usecase.UseCase, error) {
+	return r.UseCase, nil // want `a/usecase\.UseCase escapes \(\*a/rules\.todoResolver\)\.Dependency via return value`
+}
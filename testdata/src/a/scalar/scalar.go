@@ -0,0 +1,77 @@
+package scalar
+
+import (
+	"a/usecase"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+var uc usecase.UseCase
+
+type UserID string
+
+func (id UserID) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(string(id)))
+}
+
+func (id *UserID) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("user id must be a string")
+	}
+
+	if err := validate(s); err != nil { // want `validate \(a/usecase\.UseCase\.Fuga through a/scalar\.validate\) cannot be used in \(\*a/scalar\.UserID\)\.UnmarshalGQL, which must be pure`
+		return err
+	}
+
+	*id = UserID(s)
+
+	return nil
+}
+
+func validate(s string) error {
+	return exists(s)
+}
+
+func exists(s string) error {
+	return uc.Fuga()
+}
+
+func MarshalTodoID(id string) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/scalar\.MarshalTodoID\$1, which must be pure`
+
+		io.WriteString(w, strconv.Quote(id))
+	})
+}
+
+func UnmarshalTodoID(v interface{}) (string, error) {
+	s, _ := v.(string)
+
+	return s, uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/scalar\.UnmarshalTodoID, which must be pure`
+}
+
+func NewConfig() Config {
+	c := Config{}
+
+	c.Complexity.Query.Todos = func(childComplexity int, first int) int {
+		uc.Fuga() // want `uc\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a/scalar\.NewConfig\$1, which must be pure`
+
+		return childComplexity * first
+	}
+
+	c.Complexity.Query.Users = usersComplexity
+
+	return c
+}
+
+func usersComplexity(childComplexity int) int {
+	if validate("") != nil { // want `validate \(a/usecase\.UseCase\.Fuga through a/scalar\.validate\) cannot be used in a/scalar\.usersComplexity, which must be pure`
+		return 0
+	}
+
+	return childComplexity
+}
@@ -87,32 +87,32 @@ func nestFuncForQueryResolver(r *queryResolver, usecase usecase.UseCase) {
 // Text is the resolver for the text field.
 func (r *todoResolver) Text(ctx context.Context, obj *Todo) (string, error) {
 	r.Loader.Hoge()
-	r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 
 	err := r.Loader.Hoge()
 	if err != nil {
 	}
-	err2 := r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	err2 := r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 	if err2 != nil {
 	}
 
 	var err3 error
 	err3 = r.Loader.Hoge()
-	err3 = r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	err3 = r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 	if err3 != nil {
 	}
 
 	if err := r.Loader.Hoge(); err != nil {
 	}
-	if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	if err := r.UseCase.Fuga(); err != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 	}
 
 	if r.Loader.Hoge() != nil {
 	}
-	if r.UseCase.Fuga() != nil { // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	if r.UseCase.Fuga() != nil { // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 	}
 
-	return "", r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.Text`
+	return "", r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.Text`
 }
 
 // User is the resolver for the user field.
@@ -133,7 +133,7 @@ func (r *todoResolver) User(ctx context.Context, obj *Todo) (*User, error) {
 	r.UseCase.Fuga()
 	// nolint:  forceloader
 	r.UseCase.Fuga()
-	r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.User`
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.User`
 
 	r.UseCase.Fuga() //nolint:forceloader
 	r.UseCase.Fuga() // nolint:forceloader
@@ -143,12 +143,12 @@ func (r *todoResolver) User(ctx context.Context, obj *Todo) (*User, error) {
 	r.UseCase.Fuga() // nolint: forceloader,hoge
 	r.UseCase.Fuga() //  nolint:forceloader
 	r.UseCase.Fuga() // nolint:  forceloader
-	r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.User`
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.User`
 
 	(func() {
 		//nolint:forceloader
 		r.UseCase.Fuga()
-		r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a\.todoResolver\).User\$1`
+		r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a\.todoResolver\).User\$1`
 	})()
 
 	nestFuncForTodoResolver(r, r.UseCase)
@@ -189,17 +189,17 @@ func (r *todoResolver) User(ctx context.Context, obj *Todo) (*User, error) {
 func nestFuncForTodoResolver(r *todoResolver, usecase usecase.UseCase) {
 	//nolint:forceloader
 	r.UseCase.Fuga()
-	r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in a\.nestFuncForTodoResolver`
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a\.nestFuncForTodoResolver`
 
 	//nolint:forceloader
 	usecase.Fuga()
-	usecase.Fuga() // want `r\.UseCase\.Fuga cannot be used in a\.nestFuncForTodoResolver`
+	usecase.Fuga() // want `usecase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in a\.nestFuncForTodoResolver`
 }
 
 func (r *todoResolver) nestFunc() {
 	//nolint:forceloader
 	r.UseCase.Fuga()
-	r.UseCase.Fuga() // want `r\.UseCase\.Fuga cannot be used in \(\*a.todoResolver\)\.nestFunc`
+	r.UseCase.Fuga() // want `r\.UseCase\.Fuga \(a/usecase\.UseCase\.Fuga\) cannot be used in \(\*a.todoResolver\)\.nestFunc`
 }
 
 // Mutation returns MutationResolver implementation.
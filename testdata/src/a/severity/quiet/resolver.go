@@ -0,0 +1,20 @@
+package quiet
+
+import (
+	"a/usecase"
+	"context"
+)
+
+type Resolver struct {
+	UseCase usecase.UseCase
+}
+
+type todoResolver struct{ *Resolver }
+
+type Todo struct {
+	ID string
+}
+
+func (r *todoResolver) User(ctx context.Context, obj *Todo) error {
+	return r.UseCase.Fuga()
+}